@@ -0,0 +1,89 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Betterment/testtrack-cli/codegenerators"
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/spf13/cobra"
+)
+
+var codegenDoc = `
+Generates typed constants for the splits in your schema so that a misspelled
+split or variant is a compile error instead of a silent fallback to the default
+variant.
+
+Every split gets a constant, experiments get an enum (or constants) of their
+variants, and feature gates get boolean accessors on a FeatureGates type that
+wraps your TestTrack client's variant lookup.
+
+Splits prefixed with your app name are named without the prefix. Regenerate
+whenever the schema changes, e.g. after 'testtrack create' or in CI.
+
+With --output json, the generated code is printed as the "code" field of a
+JSON object unless --out is given.
+
+Example:
+
+testtrack codegen --lang ts --out web/src/testtrack.ts
+testtrack codegen --lang go --package flags --out internal/flags/testtrack.go
+`
+
+var codegenLang string
+var codegenOut string
+var codegenPackage string
+
+func init() {
+	codegenCmd.Flags().StringVar(&codegenLang, "lang", "", fmt.Sprintf("Language to generate (%s)", strings.Join(codegenerators.Languages, ", ")))
+	codegenCmd.MarkFlagRequired("lang")
+	codegenCmd.Flags().StringVar(&codegenOut, "out", "", "File to write to instead of stdout")
+	codegenCmd.Flags().StringVar(&codegenPackage, "package", "", "Go or Kotlin package, or Ruby module, to generate into")
+	rootCmd.AddCommand(codegenCmd)
+}
+
+var codegenCmd = &cobra.Command{
+	Use:   "codegen",
+	Short: "Generate typed split and variant constants from the schema",
+	Long:  codegenDoc,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return codegen(codegenLang, codegenOut, codegenPackage)
+	},
+}
+
+func codegen(lang, out, packageName string) error {
+	appName, err := getAppName()
+	if err != nil {
+		return err
+	}
+
+	schema, err := schema.Read()
+	if err != nil {
+		return err
+	}
+
+	code, err := codegenerators.Generate(lang, schema, appName, packageName)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		if jsonOutput() {
+			return printJSON(map[string]string{"lang": lang, "code": string(code)})
+		}
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+
+	err = os.WriteFile(out, code, 0644)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(map[string]string{"lang": lang, "file": out})
+	}
+	return nil
+}
@@ -18,3 +18,12 @@ var createCmd = &cobra.Command{
 	Short: "Create a TestTrack resource",
 	Long:  createDoc,
 }
+
+// optionalFlag returns nil for a flag that wasn't set so that it's omitted
+// from migration files
+func optionalFlag(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -3,7 +3,6 @@ package cmds
 import (
 	"fmt"
 
-	"github.com/Betterment/testtrack-cli/migrationmanagers"
 	"github.com/Betterment/testtrack-cli/schema"
 	"github.com/Betterment/testtrack-cli/splits"
 	"github.com/Betterment/testtrack-cli/validations"
@@ -35,6 +34,7 @@ func init() {
 	createExperimentCmd.Flags().StringVar(&createExperimentOwner, "owner", "", "Who owns this feature flag?")
 	createExperimentCmd.Flags().StringVar(&createExperimentWeights, "weights", "control: 50, treatment: 50", "Variant weights to use")
 	createExperimentCmd.Flags().BoolVar(&noPrefix, "no-prefix", false, "Don't prefix experiment with app_name (supports existing legacy splits)")
+	addSplitDetailsFlags(createExperimentCmd)
 	createCmd.AddCommand(createExperimentCmd)
 }
 
@@ -44,11 +44,11 @@ var createExperimentCmd = &cobra.Command{
 	Long:  createExperimentDoc,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return createExperiment(args[0], createExperimentWeights, createExperimentOwner)
+		return createExperiment(cmd, args[0], createExperimentWeights, createExperimentOwner)
 	},
 }
 
-func createExperiment(name, weights string, owner string) error {
+func createExperiment(cmd *cobra.Command, name, weights string, owner string) error {
 	schema, err := schema.Read()
 	if err != nil {
 		return err
@@ -82,17 +82,17 @@ func createExperiment(name, weights string, owner string) error {
 		return err
 	}
 
-	split, err := splits.New(&name, weightsMap, &owner)
+	details, err := splitDetailsFromFlags(cmd, schema, name)
 	if err != nil {
 		return err
 	}
 
-	mgr, err := migrationmanagers.New(split)
+	split, err := splits.New(&name, weightsMap, &owner, details)
 	if err != nil {
 		return err
 	}
 
-	err = mgr.CreateMigration()
+	err = createMigration(split)
 	if err != nil {
 		return err
 	}
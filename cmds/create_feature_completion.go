@@ -2,7 +2,6 @@ package cmds
 
 import (
 	"github.com/Betterment/testtrack-cli/featurecompletions"
-	"github.com/Betterment/testtrack-cli/migrationmanagers"
 	"github.com/Betterment/testtrack-cli/schema"
 	"github.com/Betterment/testtrack-cli/validations"
 	"github.com/spf13/cobra"
@@ -24,14 +23,17 @@ Server-side apps will typically ignore this setting and show features
 regardless of feature-completeness because there is no legacy code in the
 field for customers to use.
 
-You can reverse complete_feature with the uncomplete_feature command.
+You can reverse complete_feature with the uncomplete_feature command. If you
+pass --expires_at, 'testtrack validate' fails from the start of that date (UTC) and
+'testtrack expire' writes the destroy migration for you.
 `
 
-var createFeatureCompletionAppVersion string
+var createFeatureCompletionAppVersion, createFeatureCompletionExpiresAt string
 
 func init() {
 	createFeatureCompletionCmd.Flags().StringVar(&createFeatureCompletionAppVersion, "app_version", "", "App version (required)")
 	createFeatureCompletionCmd.MarkFlagRequired("app_version")
+	createFeatureCompletionCmd.Flags().StringVar(&createFeatureCompletionExpiresAt, "expires_at", "", "Date (YYYY-MM-DD, UTC) on or after which the feature completion should be destroyed")
 	createFeatureCompletionCmd.Flags().BoolVar(&noPrefix, "no-prefix", false, "Don't prefix feature gate with app_name to refer to legacy splits")
 	createFeatureCompletionCmd.Flags().BoolVar(&force, "force", false, "Force creation if feature gate isn't found in schema, e.g. if split is retired")
 	createCmd.AddCommand(createFeatureCompletionCmd)
@@ -43,11 +45,11 @@ var createFeatureCompletionCmd = &cobra.Command{
 	Long:  createFeatureCompletionDoc,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return createFeatureCompletion(&args[0], &createFeatureCompletionAppVersion)
+		return createFeatureCompletion(&args[0], &createFeatureCompletionAppVersion, optionalFlag(createFeatureCompletionExpiresAt))
 	},
 }
 
-func createFeatureCompletion(featureGate, version *string) error {
+func createFeatureCompletion(featureGate, version, expiresAt *string) error {
 	currentAppName, err := getAppName()
 	if err != nil {
 		return err
@@ -71,17 +73,12 @@ func createFeatureCompletion(featureGate, version *string) error {
 		return err
 	}
 
-	featureCompletion, err := featurecompletions.New(featureGate, version)
+	featureCompletion, err := featurecompletions.New(featureGate, version, expiresAt)
 	if err != nil {
 		return err
 	}
 
-	mgr, err := migrationmanagers.New(featureCompletion)
-	if err != nil {
-		return err
-	}
-
-	err = mgr.CreateMigration()
+	err = createMigration(featureCompletion)
 	if err != nil {
 		return err
 	}
@@ -3,7 +3,6 @@ package cmds
 import (
 	"fmt"
 
-	"github.com/Betterment/testtrack-cli/migrationmanagers"
 	"github.com/Betterment/testtrack-cli/schema"
 	"github.com/Betterment/testtrack-cli/splits"
 	"github.com/Betterment/testtrack-cli/validations"
@@ -40,6 +39,7 @@ func init() {
 	createFeatureGateCmd.Flags().StringVar(&createFeatureGateDefault, "default", "false", "Default variant for your feature flag")
 	createFeatureGateCmd.Flags().StringVar(&createFeatureGateWeights, "weights", "", "Variant weights to use (overrides default)")
 	createFeatureGateCmd.Flags().BoolVar(&noPrefix, "no-prefix", false, "Don't prefix feature gate with app_name (supports existing legacy splits)")
+	addSplitDetailsFlags(createFeatureGateCmd)
 	createCmd.AddCommand(createFeatureGateCmd)
 }
 
@@ -49,11 +49,11 @@ var createFeatureGateCmd = &cobra.Command{
 	Long:  createFeatureGateDoc,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return createFeatureGate(args[0], createFeatureGateDefault, createFeatureGateWeights, createFeatureGateOwner)
+		return createFeatureGate(cmd, args[0], createFeatureGateDefault, createFeatureGateWeights, createFeatureGateOwner)
 	},
 }
 
-func createFeatureGate(name, defaultVariant, weights string, owner string) error {
+func createFeatureGate(cmd *cobra.Command, name, defaultVariant, weights string, owner string) error {
 	schema, err := schema.Read()
 	if err != nil {
 		return err
@@ -110,17 +110,17 @@ func createFeatureGate(name, defaultVariant, weights string, owner string) error
 		return fmt.Errorf("weights %v are missing false variant", *weightsMap)
 	}
 
-	split, err := splits.New(&name, weightsMap, &createFeatureGateOwner)
+	details, err := splitDetailsFromFlags(cmd, schema, name)
 	if err != nil {
 		return err
 	}
 
-	mgr, err := migrationmanagers.New(split)
+	split, err := splits.New(&name, weightsMap, &createFeatureGateOwner, details)
 	if err != nil {
 		return err
 	}
 
-	err = mgr.CreateMigration()
+	err = createMigration(split)
 	if err != nil {
 		return err
 	}
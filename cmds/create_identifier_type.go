@@ -2,7 +2,6 @@ package cmds
 
 import (
 	"github.com/Betterment/testtrack-cli/identifiertypes"
-	"github.com/Betterment/testtrack-cli/migrationmanagers"
 	"github.com/Betterment/testtrack-cli/validations"
 	"github.com/spf13/cobra"
 )
@@ -44,12 +43,7 @@ func createIdentifierType(name string) error {
 		return err
 	}
 
-	mgr, err := migrationmanagers.New(identifierType)
-	if err != nil {
-		return err
-	}
-
-	err = mgr.CreateMigration()
+	err = createMigration(identifierType)
 	if err != nil {
 		return err
 	}
@@ -1,7 +1,6 @@
 package cmds
 
 import (
-	"github.com/Betterment/testtrack-cli/migrationmanagers"
 	"github.com/Betterment/testtrack-cli/remotekills"
 	"github.com/Betterment/testtrack-cli/schema"
 	"github.com/Betterment/testtrack-cli/validations"
@@ -28,10 +27,12 @@ Server-side apps will typically ignore this setting and show features
 regardless of remote kill state because they can simply decide the split until
 the bug can be fixed and then undecide it afterward.
 
-You can reverse remote_kills with the destroy remote_kill command.
+You can reverse remote_kills with the destroy remote_kill command. If you pass
+--expires_at, 'testtrack validate' fails from the start of that date (UTC) and
+'testtrack expire' writes the destroy migration for you.
 `
 
-var createRemoteKillOverrideTo, createRemoteKillFirstBadVersion, createRemoteKillFixedVersion string
+var createRemoteKillOverrideTo, createRemoteKillFirstBadVersion, createRemoteKillFixedVersion, createRemoteKillExpiresAt string
 
 func init() {
 	createRemoteKillCmd.Flags().StringVar(&createRemoteKillOverrideTo, "override_to", "", "Override-to variant (required)")
@@ -39,6 +40,7 @@ func init() {
 	createRemoteKillCmd.Flags().StringVar(&createRemoteKillFirstBadVersion, "first_bad_version", "", "First bad app version (required)")
 	createRemoteKillCmd.MarkFlagRequired("first_bad_version")
 	createRemoteKillCmd.Flags().StringVar(&createRemoteKillFixedVersion, "fixed_version", "", "Fixed app version")
+	createRemoteKillCmd.Flags().StringVar(&createRemoteKillExpiresAt, "expires_at", "", "Date (YYYY-MM-DD, UTC) on or after which the remote kill should be destroyed")
 	createRemoteKillCmd.Flags().BoolVar(&noPrefix, "no-prefix", false, "Don't prefix split with app_name to refer to legacy splits")
 	createRemoteKillCmd.Flags().BoolVar(&force, "force", false, "Force creation if split isn't found in schema, e.g. if split is retired")
 	createCmd.AddCommand(createRemoteKillCmd)
@@ -50,11 +52,11 @@ var createRemoteKillCmd = &cobra.Command{
 	Long:  createRemoteKillDoc,
 	Args:  cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return createRemoteKill(&args[0], &args[1], &createRemoteKillOverrideTo, &createRemoteKillFirstBadVersion, &createRemoteKillFixedVersion)
+		return createRemoteKill(&args[0], &args[1], &createRemoteKillOverrideTo, &createRemoteKillFirstBadVersion, &createRemoteKillFixedVersion, optionalFlag(createRemoteKillExpiresAt))
 	},
 }
 
-func createRemoteKill(split, reason, overrideTo, firstBadVersion, fixedVersion *string) error {
+func createRemoteKill(split, reason, overrideTo, firstBadVersion, fixedVersion, expiresAt *string) error {
 	currentAppName, err := getAppName()
 	if err != nil {
 		return err
@@ -78,17 +80,12 @@ func createRemoteKill(split, reason, overrideTo, firstBadVersion, fixedVersion *
 		return err
 	}
 
-	remoteKill, err := remotekills.New(split, reason, overrideTo, firstBadVersion, fixedVersion)
+	remoteKill, err := remotekills.New(split, reason, overrideTo, firstBadVersion, fixedVersion, expiresAt)
 	if err != nil {
 		return err
 	}
 
-	mgr, err := migrationmanagers.New(remoteKill)
-	if err != nil {
-		return err
-	}
-
-	err = mgr.CreateMigration()
+	err = createMigration(remoteKill)
 	if err != nil {
 		return err
 	}
@@ -1,7 +1,6 @@
 package cmds
 
 import (
-	"github.com/Betterment/testtrack-cli/migrationmanagers"
 	"github.com/Betterment/testtrack-cli/schema"
 	"github.com/Betterment/testtrack-cli/splitdecisions"
 	"github.com/Betterment/testtrack-cli/validations"
@@ -73,12 +72,7 @@ func decide(name, variant string) error {
 		return err
 	}
 
-	mgr, err := migrationmanagers.New(splitDecision)
-	if err != nil {
-		return err
-	}
-
-	err = mgr.CreateMigration()
+	err = createMigration(splitDecision)
 	if err != nil {
 		return err
 	}
@@ -2,7 +2,6 @@ package cmds
 
 import (
 	"github.com/Betterment/testtrack-cli/featurecompletions"
-	"github.com/Betterment/testtrack-cli/migrationmanagers"
 	"github.com/Betterment/testtrack-cli/schema"
 	"github.com/Betterment/testtrack-cli/validations"
 	"github.com/spf13/cobra"
@@ -49,17 +48,12 @@ func destroyFeatureCompletion(featureGate *string) error {
 		return err
 	}
 
-	featureCompletion, err := featurecompletions.New(featureGate, nil)
+	featureCompletion, err := featurecompletions.New(featureGate, nil, nil)
 	if err != nil {
 		return err
 	}
 
-	mgr, err := migrationmanagers.New(featureCompletion)
-	if err != nil {
-		return err
-	}
-
-	err = mgr.CreateMigration()
+	err = createMigration(featureCompletion)
 	if err != nil {
 		return err
 	}
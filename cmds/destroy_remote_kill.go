@@ -1,7 +1,6 @@
 package cmds
 
 import (
-	"github.com/Betterment/testtrack-cli/migrationmanagers"
 	"github.com/Betterment/testtrack-cli/remotekills"
 	"github.com/Betterment/testtrack-cli/schema"
 	"github.com/Betterment/testtrack-cli/validations"
@@ -47,17 +46,12 @@ func destroyRemoteKill(split, reason *string) error {
 		return err
 	}
 
-	remoteKill, err := remotekills.New(split, reason, nil, nil, nil)
+	remoteKill, err := remotekills.New(split, reason, nil, nil, nil, nil)
 	if err != nil {
 		return err
 	}
 
-	mgr, err := migrationmanagers.New(remoteKill)
-	if err != nil {
-		return err
-	}
-
-	err = mgr.CreateMigration()
+	err = createMigration(remoteKill)
 	if err != nil {
 		return err
 	}
@@ -1,7 +1,6 @@
 package cmds
 
 import (
-	"github.com/Betterment/testtrack-cli/migrationmanagers"
 	"github.com/Betterment/testtrack-cli/schema"
 	"github.com/Betterment/testtrack-cli/splitretirements"
 	"github.com/Betterment/testtrack-cli/validations"
@@ -78,12 +77,7 @@ func destroySplit(name, decision string) error {
 		return err
 	}
 
-	mgr, err := migrationmanagers.New(splitRetirement)
-	if err != nil {
-		return err
-	}
-
-	err = mgr.CreateMigration()
+	err = createMigration(splitRetirement)
 	if err != nil {
 		return err
 	}
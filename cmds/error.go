@@ -4,6 +4,8 @@ package cmds
 type ExitStatusAwareError struct {
 	description string
 	exitStatus  int
+	// reported means the command's JSON output already describes the failure
+	reported bool
 }
 
 // Error returns the error description
@@ -15,3 +17,12 @@ func (e *ExitStatusAwareError) Error() string {
 func (e *ExitStatusAwareError) ExitStatus() int {
 	return e.exitStatus
 }
+
+// Exit statuses, which double as error codes in JSON output
+const (
+	// exitStatusError signals that a command failed
+	exitStatusError = 1
+	// exitStatusDrift signals that a check ran successfully but found
+	// problems, so CI can tell it apart from a failure to run the check at all
+	exitStatusDrift = 2
+)
@@ -0,0 +1,101 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/fakeevents"
+	"github.com/spf13/cobra"
+)
+
+var eventsDoc = `
+Lists the requests clients made to the fake TestTrack server: assignment
+events, identifier posts and assignment override posts, oldest first.
+
+Filter by split with --split (prefixed with your app name unless it's already
+prefixed or you pass --no-prefix), by visitor with --visitor, and by time with
+--since, which takes an RFC3339 timestamp or a duration like 15m. Pass --clear
+to empty the event log.
+
+The event log is capped at 10 MB. Past that it's rotated to events.jsonl.1,
+so only about the last 20 MB of events are kept.
+
+Example:
+
+testtrack events --split my_fancy_experiment --since 15m
+`
+
+var eventsSplit, eventsVisitor, eventsSince string
+var eventsClear bool
+
+func init() {
+	eventsCmd.Flags().StringVar(&eventsSplit, "split", "", "Only list events for this split")
+	eventsCmd.Flags().StringVar(&eventsVisitor, "visitor", "", "Only list events for this visitor ID")
+	eventsCmd.Flags().StringVar(&eventsSince, "since", "", "Only list events since this RFC3339 timestamp or duration ago")
+	eventsCmd.Flags().BoolVar(&eventsClear, "clear", false, "Empty the event log")
+	eventsCmd.Flags().BoolVar(&noPrefix, "no-prefix", false, "Don't prefix split with app_name (supports legacy splits)")
+	rootCmd.AddCommand(eventsCmd)
+}
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "List requests clients made to the fake server",
+	Long:  eventsDoc,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if eventsClear {
+			return fakeevents.Clear()
+		}
+		return events(eventsSplit, eventsVisitor, eventsSince)
+	},
+}
+
+func events(split, visitor, since string) error {
+	var err error
+	if split != "" {
+		split, err = prefixSplitName(split)
+		if err != nil {
+			return err
+		}
+	}
+	sinceTime, err := fakeevents.ParseSince(since, time.Now())
+	if err != nil {
+		return err
+	}
+
+	events, err := fakeevents.Read(fakeevents.Filter{
+		SplitName: split,
+		VisitorID: visitor,
+		Since:     sinceTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(map[string][]fakeevents.Event{"events": events})
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tKIND\tVISITOR\tSPLIT\tVARIANT\tDETAIL")
+	for _, event := range events {
+		detail := "-"
+		switch {
+		case event.IdentifierType != "":
+			detail = event.IdentifierType + "=" + event.IdentifierValue
+		case event.Context != "":
+			detail = "context=" + event.Context
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			event.Time.Local().Format(time.RFC3339),
+			event.Kind,
+			optionalString(&event.VisitorID),
+			optionalString(&event.SplitName),
+			optionalString(&event.Variant),
+			detail,
+		)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,94 @@
+package cmds
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/expirations"
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/spf13/cobra"
+)
+
+var expireDoc = `
+Writes destroy migrations for every remote kill and feature completion whose
+expires_at date has arrived, and applies them to your local schema. Commit the
+migrations and deploy them like any other.
+
+Set expires_at with the --expires_at flag of 'create remote_kill' and 'create
+feature_completion'. Resources expire at the start of their expires_at date,
+UTC.
+
+Example:
+
+testtrack expire
+`
+
+var expireDryRun bool
+
+func init() {
+	expireCmd.Flags().BoolVar(&expireDryRun, "dry-run", false, "Print expired resources without writing migrations")
+	rootCmd.AddCommand(expireCmd)
+}
+
+var expireCmd = &cobra.Command{
+	Use:   "expire",
+	Short: "Destroy expired remote kills and feature completions",
+	Long:  expireDoc,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return expire(expireDryRun)
+	},
+}
+
+// expiredMigration is the JSON output of a destroy migration for an expired
+// resource
+type expiredMigration struct {
+	expirations.Expiration
+	Migration *createdMigration `json:"migration,omitempty"`
+}
+
+func expire(dryRun bool) error {
+	schema, err := schema.Read()
+	if err != nil {
+		return err
+	}
+
+	expired, err := expirations.Find(schema, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	results := []expiredMigration{}
+	for _, expiration := range expired {
+		result := expiredMigration{Expiration: expiration}
+		if !dryRun {
+			migration, err := expiration.DestroyMigration()
+			if err != nil {
+				return err
+			}
+			created, err := persistMigration(migration)
+			if err != nil {
+				return err
+			}
+			result.Migration = &created
+		}
+		results = append(results, result)
+	}
+
+	if jsonOutput() {
+		return printJSON(map[string][]expiredMigration{"expired": results})
+	}
+
+	if len(results) == 0 {
+		fmt.Println("Nothing has expired")
+		return nil
+	}
+	for _, result := range results {
+		if result.Migration == nil {
+			fmt.Printf("%s %s expired on %s\n", result.Kind, result.Resource, result.ExpiresAt)
+			continue
+		}
+		fmt.Printf("Created %s to destroy %s %s, which expired on %s\n", result.Migration.Filename, result.Kind, result.Resource, result.ExpiresAt)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/Betterment/testtrack-cli/histories"
+	"github.com/Betterment/testtrack-cli/migrationloaders"
+	"github.com/spf13/cobra"
+)
+
+var historyDoc = `
+Prints the audit trail of a split from the migrations in testtrack/migrate:
+every create, reweight, decision, retirement, remote kill and feature
+completion affecting it, oldest first.
+
+Each entry shows the UTC time encoded in its migration version and, when the
+migration file has been committed, the git author who added it.
+
+The split name is prefixed with your app name unless it's already prefixed or
+you pass --no-prefix.
+
+Example:
+
+testtrack history my_fancy_experiment
+`
+
+func init() {
+	historyCmd.Flags().BoolVar(&noPrefix, "no-prefix", false, "Don't prefix split with app_name (supports legacy splits)")
+	rootCmd.AddCommand(historyCmd)
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history split_name",
+	Short: "Show the migration history of a split",
+	Long:  historyDoc,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return history(args[0])
+	},
+}
+
+func history(name string) error {
+	name, err := prefixSplitName(name)
+	if err != nil {
+		return err
+	}
+
+	migrationRepo, err := migrationloaders.Load()
+	if err != nil {
+		return err
+	}
+
+	entries := histories.ForSplit(name, migrationRepo)
+	if len(entries) == 0 {
+		return fmt.Errorf("no migrations found for split %s", name)
+	}
+	paths, err := migrationloaders.Paths()
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		path, ok := paths[entries[i].Version]
+		if !ok {
+			continue
+		}
+		entries[i].Filename = filepath.Base(path)
+		entries[i].Author = histories.Author(path)
+	}
+
+	if jsonOutput() {
+		return printJSON(map[string][]histories.Entry{"history": entries})
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tVERSION\tACTION\tAUTHOR\tDESCRIPTION")
+	for _, entry := range entries {
+		author := entry.Author
+		if author == "" {
+			author = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", entry.Time.Format("2006-01-02 15:04:05"), entry.Version, entry.Action, author, entry.Description)
+	}
+	return w.Flush()
+}
@@ -39,7 +39,7 @@ func initProject() error {
 	}
 	keepfile.Close()
 
-	if err := os.WriteFile("testtrack/.gitignore", []byte("build_timestamp\n"), 0644); err != nil {
+	if err := os.WriteFile("testtrack/.gitignore", []byte("build_timestamp\nmigrate_journal.yml\n"), 0644); err != nil {
 		log.Fatal(err)
 	}
 
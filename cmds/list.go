@@ -0,0 +1,56 @@
+package cmds
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Betterment/testtrack-cli/splits"
+	"github.com/spf13/cobra"
+)
+
+var listDoc = `
+List resources in your local testtrack/schema.{json,yml}, optionally filtered.
+`
+
+var listOwner string
+var listApp string
+var listKind string
+var listDecided bool
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List TestTrack resources in the schema",
+	Long:  listDoc,
+}
+
+func validateListKind() error {
+	if listKind != "" && listKind != "feature_gate" && listKind != "experiment" {
+		return fmt.Errorf("kind %s must be either 'feature_gate' or 'experiment'", listKind)
+	}
+	return nil
+}
+
+// listMatchesSplit applies the --app and --kind filters to a split name
+func listMatchesSplit(name string) bool {
+	if listApp != "" && !strings.HasPrefix(name, listApp+".") {
+		return false
+	}
+	switch listKind {
+	case "feature_gate":
+		return splits.IsFeatureGateFromName(name)
+	case "experiment":
+		return splits.IsExperimentFromName(name)
+	}
+	return true
+}
+
+func optionalString(value *string) string {
+	if value == nil || *value == "" {
+		return "-"
+	}
+	return *value
+}
@@ -0,0 +1,59 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/spf13/cobra"
+)
+
+var listFeatureCompletionsDoc = `
+Lists the feature completions in your schema.
+
+Example:
+
+testtrack list feature_completions --app my_app
+`
+
+func init() {
+	listFeatureCompletionsCmd.Flags().StringVar(&listApp, "app", "", "Only list feature completions of feature gates prefixed with this app name")
+	listCmd.AddCommand(listFeatureCompletionsCmd)
+}
+
+var listFeatureCompletionsCmd = &cobra.Command{
+	Use:   "feature_completions",
+	Short: "List feature completions",
+	Long:  listFeatureCompletionsDoc,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return listFeatureCompletions()
+	},
+}
+
+func listFeatureCompletions() error {
+	schema, err := schema.Read()
+	if err != nil {
+		return err
+	}
+
+	matches := []serializers.FeatureCompletion{}
+	for _, featureCompletion := range schema.FeatureCompletions {
+		if listMatchesSplit(featureCompletion.FeatureGate) {
+			matches = append(matches, featureCompletion)
+		}
+	}
+
+	if jsonOutput() {
+		return printJSON(map[string][]serializers.FeatureCompletion{"feature_completions": matches})
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FEATURE_GATE\tVERSION\tEXPIRES_AT")
+	for _, featureCompletion := range matches {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", featureCompletion.FeatureGate, optionalString(featureCompletion.Version), optionalString(featureCompletion.ExpiresAt))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,48 @@
+package cmds
+
+import (
+	"fmt"
+
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/spf13/cobra"
+)
+
+var listIdentifierTypesDoc = `
+Lists the identifier types in your schema.
+`
+
+func init() {
+	listCmd.AddCommand(listIdentifierTypesCmd)
+}
+
+var listIdentifierTypesCmd = &cobra.Command{
+	Use:   "identifier_types",
+	Short: "List identifier types",
+	Long:  listIdentifierTypesDoc,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return listIdentifierTypes()
+	},
+}
+
+func listIdentifierTypes() error {
+	schema, err := schema.Read()
+	if err != nil {
+		return err
+	}
+
+	identifierTypes := schema.IdentifierTypes
+	if identifierTypes == nil {
+		identifierTypes = []serializers.IdentifierType{}
+	}
+
+	if jsonOutput() {
+		return printJSON(map[string][]serializers.IdentifierType{"identifier_types": identifierTypes})
+	}
+
+	for _, identifierType := range identifierTypes {
+		fmt.Println(identifierType.Name)
+	}
+	return nil
+}
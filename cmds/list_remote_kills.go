@@ -0,0 +1,65 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/spf13/cobra"
+)
+
+var listRemoteKillsDoc = `
+Lists the remote kills in your schema.
+
+Example:
+
+testtrack list remote_kills --app my_app
+`
+
+func init() {
+	listRemoteKillsCmd.Flags().StringVar(&listApp, "app", "", "Only list remote kills of splits prefixed with this app name")
+	listRemoteKillsCmd.Flags().StringVar(&listKind, "kind", "", "Only list remote kills of splits of this kind (feature_gate or experiment)")
+	listCmd.AddCommand(listRemoteKillsCmd)
+}
+
+var listRemoteKillsCmd = &cobra.Command{
+	Use:   "remote_kills",
+	Short: "List remote kills",
+	Long:  listRemoteKillsDoc,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return listRemoteKills()
+	},
+}
+
+func listRemoteKills() error {
+	err := validateListKind()
+	if err != nil {
+		return err
+	}
+
+	schema, err := schema.Read()
+	if err != nil {
+		return err
+	}
+
+	matches := []serializers.RemoteKill{}
+	for _, remoteKill := range schema.RemoteKills {
+		if listMatchesSplit(remoteKill.Split) {
+			matches = append(matches, remoteKill)
+		}
+	}
+
+	if jsonOutput() {
+		return printJSON(map[string][]serializers.RemoteKill{"remote_kills": matches})
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SPLIT\tREASON\tOVERRIDE_TO\tFIRST_BAD_VERSION\tFIXED_VERSION\tEXPIRES_AT")
+	for _, remoteKill := range matches {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", remoteKill.Split, remoteKill.Reason, optionalString(remoteKill.OverrideTo), optionalString(remoteKill.FirstBadVersion), optionalString(remoteKill.FixedVersion), optionalString(remoteKill.ExpiresAt))
+	}
+	return w.Flush()
+}
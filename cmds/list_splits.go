@@ -0,0 +1,77 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/splits"
+	"github.com/spf13/cobra"
+)
+
+var listSplitsDoc = `
+Lists the splits in your schema along with their weights, decision status and
+owner.
+
+Example:
+
+testtrack list splits --kind experiment --owner my_team
+`
+
+func init() {
+	listSplitsCmd.Flags().StringVar(&listOwner, "owner", "", "Only list splits owned by this owner")
+	listSplitsCmd.Flags().StringVar(&listApp, "app", "", "Only list splits prefixed with this app name")
+	listSplitsCmd.Flags().StringVar(&listKind, "kind", "", "Only list splits of this kind (feature_gate or experiment)")
+	listSplitsCmd.Flags().BoolVar(&listDecided, "decided", false, "Only list decided splits")
+	listCmd.AddCommand(listSplitsCmd)
+}
+
+var listSplitsCmd = &cobra.Command{
+	Use:   "splits",
+	Short: "List splits",
+	Long:  listSplitsDoc,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return listSplits()
+	},
+}
+
+func listSplits() error {
+	err := validateListKind()
+	if err != nil {
+		return err
+	}
+
+	schema, err := schema.Read()
+	if err != nil {
+		return err
+	}
+
+	matches := []serializers.SchemaSplit{}
+	for _, split := range schema.Splits {
+		if !listMatchesSplit(split.Name) {
+			continue
+		}
+		if listOwner != "" && split.Owner != listOwner {
+			continue
+		}
+		if listDecided && !split.Decided {
+			continue
+		}
+		matches = append(matches, split)
+	}
+
+	if jsonOutput() {
+		return printJSON(map[string][]serializers.SchemaSplit{"splits": matches})
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tWEIGHTS\tDECIDED\tOWNER")
+	for _, split := range matches {
+		weights := splits.Weights(split.Weights)
+		fmt.Fprintf(w, "%s\t%s\t%t\t%s\n", split.Name, weights.String(), split.Decided, split.Owner)
+	}
+	return w.Flush()
+}
@@ -1,16 +1,47 @@
 package cmds
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/expirations"
 	"github.com/Betterment/testtrack-cli/migrationrunners"
+	"github.com/Betterment/testtrack-cli/schema"
 	"github.com/Betterment/testtrack-cli/servers"
 	"github.com/spf13/cobra"
 )
 
 var migrateDoc = `
 Runs all migrations that haven't been applied to the TestTrack server yet.
+
+Pass --dry-run to print the outstanding migrations and the exact payloads that
+would be posted to the TestTrack server without changing anything.
+
+Progress is journaled to testtrack/migrate_journal.yml while migrations are
+applied, and the journal is added to testtrack/.gitignore. The next run only
+resumes migrations whose versions the server still lacks. A migration that was
+posted before the run was interrupted has its version recorded without being
+posted again. If the run was interrupted while posting a split or decision,
+the server's split registry is checked to see whether the post landed; any
+other migration whose post can't be confirmed is posted again.
+
+Remote kills and feature completions past their expires_at date are reported
+as warnings on stderr. Run 'testtrack expire' to destroy them.
 `
 
+var migrateDryRun bool
+
+// migrateFailure is the JSON output of a migrate run that failed partway
+type migrateFailure struct {
+	Applied []string  `json:"applied"`
+	Error   jsonError `json:"error"`
+}
+
 func init() {
+	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Print outstanding migrations without applying them")
 	rootCmd.AddCommand(migrateCmd)
 }
 
@@ -20,6 +51,9 @@ var migrateCmd = &cobra.Command{
 	Long:  migrateDoc,
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if migrateDryRun {
+			return migratePlan()
+		}
 		return migrate()
 	},
 }
@@ -34,10 +68,91 @@ func migrate() error {
 		return err
 	}
 
-	err = runner.RunOutstanding()
+	applied, err := runner.RunOutstanding()
+	if err != nil {
+		if !jsonOutput() {
+			return err
+		}
+		// Report the versions applied before the failure so scripts can tell
+		// what changed on the server
+		printJSON(migrateFailure{
+			Applied: applied,
+			Error:   jsonError{Message: err.Error(), Code: exitStatusError},
+		})
+		return &ExitStatusAwareError{
+			description: err.Error(),
+			exitStatus:  exitStatusError,
+			reported:    true,
+		}
+	}
+
+	warnExpired()
+
+	if jsonOutput() {
+		return printJSON(map[string][]string{"applied": applied})
+	}
+	return nil
+}
+
+// warnExpired prints a warning for each expired remote kill and feature
+// completion. It's best-effort because migrations have already been applied.
+func warnExpired() {
+	schema, err := schema.Read()
+	if err != nil {
+		return
+	}
+	expired, err := expirations.Find(schema, time.Now().UTC())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", err)
+		return
+	}
+	for _, expiration := range expired {
+		fmt.Fprintf(os.Stderr, "Warning: %s %s expired on %s; run 'testtrack expire' to destroy it\n", expiration.Kind, expiration.Resource, expiration.ExpiresAt)
+	}
+}
+
+func migratePlan() error {
+	server, err := servers.New()
+	if err != nil {
+		return err
+	}
+	runner, err := migrationrunners.New(server)
+	if err != nil {
+		return err
+	}
+
+	plan, err := runner.PlanOutstanding()
 	if err != nil {
 		return err
 	}
 
+	if jsonOutput() {
+		return printJSON(map[string][]migrationrunners.PlannedMigration{"plan": plan})
+	}
+
+	if len(plan) == 0 {
+		fmt.Println("No outstanding migrations")
+		return nil
+	}
+
+	fmt.Printf("%d outstanding migration(s) would be applied:\n", len(plan))
+	for _, planned := range plan {
+		payload, err := json.MarshalIndent(planned.Payload, "    ", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\n%s %s %s\n", planned.Version, planned.Kind, planned.Resource)
+		if planned.VersionOnly {
+			fmt.Println("  already synced by an interrupted run; only the version would be recorded")
+			continue
+		}
+		if planned.SyncPath == "" {
+			fmt.Println("  local only; nothing would be posted, only the version recorded")
+			continue
+		}
+		fmt.Printf("  POST %s\n", planned.SyncPath)
+		fmt.Printf("    %s\n", strings.TrimSpace(string(payload)))
+	}
+
 	return nil
 }
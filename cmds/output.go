@@ -0,0 +1,82 @@
+package cmds
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Betterment/testtrack-cli/migrationmanagers"
+	"github.com/Betterment/testtrack-cli/migrations"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+var outputFormat string
+
+// createdMigration is the JSON output of commands that create a migration
+type createdMigration struct {
+	Filename string `json:"filename"`
+	Version  string `json:"version"`
+}
+
+// jsonError is the JSON output of a failed command. Code matches the process
+// exit status.
+type jsonError struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+func validateOutputFormat() error {
+	if outputFormat != outputText && outputFormat != outputJSON {
+		return fmt.Errorf("output %s must be either '%s' or '%s'", outputFormat, outputText, outputJSON)
+	}
+	return nil
+}
+
+func jsonOutput() bool {
+	return outputFormat == outputJSON
+}
+
+func printJSON(v interface{}) error {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// createMigration validates and persists a migration, updating the schema, and
+// reports the created file when JSON output is requested
+func createMigration(migration migrations.IMigration) error {
+	created, err := persistMigration(migration)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(map[string]createdMigration{"migration": created})
+	}
+	return nil
+}
+
+// persistMigration validates and persists a migration, updating the schema,
+// for commands that report the created files themselves
+func persistMigration(migration migrations.IMigration) (createdMigration, error) {
+	mgr, err := migrationmanagers.New(migration)
+	if err != nil {
+		return createdMigration{}, err
+	}
+
+	err = mgr.CreateMigration()
+	if err != nil {
+		return createdMigration{}, err
+	}
+
+	return createdMigration{
+		Filename: *migration.Filename(),
+		Version:  *migration.MigrationVersion(),
+	}, nil
+}
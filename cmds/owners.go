@@ -0,0 +1,20 @@
+package cmds
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var ownersDoc = `
+Inspect split ownership as defined by testtrack/owners.yml and the owners
+assigned to splits in your schema.
+`
+
+func init() {
+	rootCmd.AddCommand(ownersCmd)
+}
+
+var ownersCmd = &cobra.Command{
+	Use:   "owners",
+	Short: "Inspect split ownership",
+	Long:  ownersDoc,
+}
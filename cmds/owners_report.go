@@ -0,0 +1,85 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/migrationloaders"
+	"github.com/Betterment/testtrack-cli/ownerreports"
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/Betterment/testtrack-cli/validations"
+	"github.com/spf13/cobra"
+)
+
+var ownersReportDoc = `
+Lists each owner's splits with their age in days since they were created (or
+last revived) and whether they've been decided. Owners in testtrack/owners.yml
+with no splits are listed too, and splits without an owner are listed under
+(unowned).
+
+Splits that predate migrations can't be aged and show '-'.
+
+Example:
+
+testtrack owners report
+`
+
+func init() {
+	ownersCmd.AddCommand(ownersReportCmd)
+}
+
+var ownersReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "List each owner's splits with their age and decision status",
+	Long:  ownersReportDoc,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return ownersReport()
+	},
+}
+
+func ownersReport() error {
+	schema, err := schema.Read()
+	if err != nil {
+		return err
+	}
+
+	migrationRepo, err := migrationloaders.Load()
+	if err != nil {
+		return err
+	}
+
+	owners, err := validations.Owners()
+	if err != nil {
+		return err
+	}
+
+	report := ownerreports.Build(schema, migrationRepo, owners, time.Now().UTC())
+
+	if jsonOutput() {
+		return printJSON(map[string][]ownerreports.Owner{"owners": report})
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "OWNER\tSPLIT\tAGE\tSTATUS")
+	for _, owner := range report {
+		if len(owner.Splits) == 0 {
+			fmt.Fprintf(w, "%s\t-\t-\t-\n", owner.Owner)
+			continue
+		}
+		for _, split := range owner.Splits {
+			age := "-"
+			if split.Days != nil {
+				age = fmt.Sprintf("%dd", *split.Days)
+			}
+			status := "undecided"
+			if split.Decided {
+				status = "decided " + split.Decision
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", owner.Owner, split.Name, age, status)
+		}
+	}
+	return w.Flush()
+}
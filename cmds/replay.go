@@ -0,0 +1,132 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Betterment/testtrack-cli/recordings"
+	"github.com/Betterment/testtrack-cli/servers"
+	"github.com/spf13/cobra"
+)
+
+var replayDoc = `
+Re-issues the requests in a recording made with 'testtrack server --record'
+against a TestTrack server, fake or real, and reports where its responses
+differ from the recorded ones. Use it for contract tests between the fake
+server and production TestTrack.
+
+Requests are sent to --url, which defaults to TESTTRACK_CLI_URL if it's set
+and the fake server at http://localhost:8297 otherwise. They're authenticated
+like other commands that talk to TestTrack, with TESTTRACK_CLI_TOKEN or
+TESTTRACK_CLI_PASSWORD (or their _FILE variants), so keep secrets out of the
+URL.
+
+JSON responses are compared structurally. Pass --ignore-field for keys that
+legitimately differ between servers, like visitor ids, and --ignore-order if
+the servers order arrays differently.
+
+Exits with status 2 if any response differs.
+
+Example:
+
+testtrack replay recording.jsonl --url https://testtrack.example.com --ignore-field id
+`
+
+var replayURL string
+var replayIgnoreFields []string
+var replayIgnoreOrder bool
+
+func init() {
+	replayCmd.Flags().StringVar(&replayURL, "url", "", "Server to replay requests against")
+	replayCmd.Flags().StringArrayVar(&replayIgnoreFields, "ignore-field", []string{}, "JSON key to leave out of comparisons (repeatable)")
+	replayCmd.Flags().BoolVar(&replayIgnoreOrder, "ignore-order", false, "Compare JSON arrays without regard to element order")
+	rootCmd.AddCommand(replayCmd)
+}
+
+var replayCmd = &cobra.Command{
+	Use:   "replay recording.jsonl",
+	Short: "Replay recorded fake server requests against a server and diff the responses",
+	Long:  replayDoc,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := replay(args[0])
+		if _, ok := err.(*ExitStatusAwareError); ok {
+			cmd.SilenceUsage = true
+		}
+		return err
+	},
+}
+
+// replayMismatch is the JSON output of a replayed request whose response
+// differed from the recording
+type replayMismatch struct {
+	Method      string   `json:"method"`
+	URL         string   `json:"url"`
+	Differences []string `json:"differences"`
+}
+
+func replay(path string) error {
+	exchanges, err := recordings.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	urlString := replayURL
+	if urlString == "" {
+		urlString = os.Getenv("TESTTRACK_CLI_URL")
+	}
+	if urlString == "" {
+		urlString = fmt.Sprintf("http://localhost:%d", defaultPort)
+	}
+	server, err := servers.NewForURL(urlString)
+	if err != nil {
+		return err
+	}
+
+	options := recordings.DiffOptions{
+		IgnoreFields: replayIgnoreFields,
+		IgnoreOrder:  replayIgnoreOrder,
+	}
+	mismatches := []replayMismatch{}
+	for _, exchange := range exchanges {
+		response, err := recordings.Replay(server, server.URL(), exchange.Request)
+		if err != nil {
+			return err
+		}
+		differences := recordings.Diff(&exchange.Response, response, options)
+		if len(differences) != 0 {
+			mismatches = append(mismatches, replayMismatch{
+				Method:      exchange.Request.Method,
+				URL:         exchange.Request.URL,
+				Differences: differences,
+			})
+		}
+	}
+
+	if jsonOutput() {
+		err := printJSON(map[string]interface{}{
+			"replayed":   len(exchanges),
+			"mismatches": mismatches,
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, mismatch := range mismatches {
+			fmt.Printf("%s %s\n", mismatch.Method, mismatch.URL)
+			for _, difference := range mismatch.Differences {
+				fmt.Printf("  %s\n", difference)
+			}
+		}
+		fmt.Printf("%d of %d responses matched\n", len(exchanges)-len(mismatches), len(exchanges))
+	}
+
+	if len(mismatches) != 0 {
+		return &ExitStatusAwareError{
+			description: fmt.Sprintf("%d replayed response(s) differed", len(mismatches)),
+			exitStatus:  exitStatusDrift,
+			reported:    jsonOutput(),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package cmds
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Betterment/testtrack-cli/migrationloaders"
+	"github.com/Betterment/testtrack-cli/migrations"
+	"github.com/Betterment/testtrack-cli/rollbacks"
+	"github.com/spf13/cobra"
+)
+
+var rollbackDoc = `
+Generates a new migration that undoes an earlier migration by restoring the
+state its split, remote kill, or feature completion was in beforehand. Applied
+migrations are never edited or deleted, so the compensating migration is
+committed and run via 'testtrack migrate' like any other.
+
+Rolling back the creation of a split retires it, which requires a decision.
+The decision of the split's most recent prior retirement is used if there is
+one, otherwise --decision is required.
+
+If later migrations also touch the same resource, they'd be undone too, so
+rollback refuses unless you pass --force.
+
+Identifier type migrations can't be rolled back.
+
+Example:
+
+testtrack rollback 2019011912345
+testtrack rollback testtrack/migrate/2019011912345_create_split_my_app_fancy_experiment.yml --decision control
+`
+
+var rollbackDecision string
+
+func init() {
+	rollbackCmd.Flags().StringVar(&rollbackDecision, "decision", "", "Variant to decide on if rolling back retires a split")
+	rollbackCmd.Flags().BoolVar(&force, "force", false, "Roll back even if later migrations touch the same resource")
+	rootCmd.AddCommand(rollbackCmd)
+}
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback version_or_filename",
+	Short: "Generate a migration that undoes an earlier migration",
+	Long:  rollbackDoc,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return rollback(args[0], rollbackDecision)
+	},
+}
+
+func rollback(versionOrFilename, decision string) error {
+	version := versionOrFilename
+	if extracted, err := migrations.ExtractVersionFromFilename(filepath.Base(versionOrFilename)); err == nil {
+		version = extracted
+	}
+
+	migrationRepo, err := migrationloaders.Load()
+	if err != nil {
+		return err
+	}
+
+	migration, err := rollbacks.Plan(version, migrationRepo, decision, force)
+	if err != nil {
+		return err
+	}
+
+	err = createMigration(migration)
+	if err != nil {
+		return err
+	}
+
+	if !jsonOutput() {
+		fmt.Printf("Created %s to roll back migration %s\n", *migration.Filename(), version)
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ var ownershipFilename string
 
 func init() {
 	godotenv.Load()
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", outputText, "Output format (text or json)")
 }
 
 var rootCmd = &cobra.Command{
@@ -27,20 +28,46 @@ var rootCmd = &cobra.Command{
 	Short:   "TestTrack Split Config Management",
 	Long:    fmt.Sprintf("CLI for managing TestTrack experiments and feature gates\n\nVersion: %s\nBuild: %s\nArch: %s", version, build, arch),
 	Version: version,
+	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+		err := validateOutputFormat()
+		if err != nil {
+			return err
+		}
+		if jsonOutput() {
+			// Errors are reported as JSON by Execute instead
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+		}
+		return nil
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
+		exitStatus := exitStatusError
+		reported := false
 		if err, ok := err.(*ExitStatusAwareError); ok {
-			os.Exit(err.ExitStatus())
+			exitStatus = err.ExitStatus()
+			reported = err.reported
 		}
-		os.Exit(1)
+		if jsonOutput() && !reported {
+			printJSON(map[string]jsonError{"error": {Message: err.Error(), Code: exitStatus}})
+		}
+		os.Exit(exitStatus)
 	}
 }
 
+// getAppName prefers explicit config, falling back to the username embedded
+// in TESTTRACK_CLI_URL for projects that haven't moved their credentials out
+// of the URL yet
 func getAppName() (string, error) {
+	appName, ok := os.LookupEnv("TESTTRACK_APP_NAME")
+	if ok {
+		return appName, nil
+	}
+
 	urlString, ok := os.LookupEnv("TESTTRACK_CLI_URL")
 	if ok {
 		url, err := url.Parse(urlString)
@@ -52,9 +79,5 @@ func getAppName() (string, error) {
 		}
 	}
 
-	appName, ok := os.LookupEnv("TESTTRACK_APP_NAME")
-	if !ok {
-		return "", errors.New("TESTTRACK_APP_NAME must be set")
-	}
-	return appName, nil
+	return "", errors.New("TESTTRACK_APP_NAME must be set")
 }
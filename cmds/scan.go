@@ -0,0 +1,93 @@
+package cmds
+
+import (
+	"fmt"
+
+	"github.com/Betterment/testtrack-cli/codescans"
+	"github.com/Betterment/testtrack-cli/migrationloaders"
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/spf13/cobra"
+)
+
+var scanDoc = `
+Searches your project for references to the splits in your schema, in both
+their prefixed (my_app.my_feature_enabled) and unprefixed (my_feature_enabled)
+forms, and reports:
+
+* splits with no references, which are safe to retire
+* quoted feature gate and experiment names that aren't in the schema, which
+  are typos or references to retired splits
+
+Hidden directories, node_modules, vendor, the testtrack directory and binary
+files are skipped.
+
+Example:
+
+testtrack scan
+testtrack scan app/
+`
+
+func init() {
+	rootCmd.AddCommand(scanCmd)
+}
+
+var scanCmd = &cobra.Command{
+	Use:   "scan [path]",
+	Short: "Find unreferenced splits and references to unknown splits in code",
+	Long:  scanDoc,
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root := "."
+		if len(args) == 1 {
+			root = args[0]
+		}
+		return scan(root)
+	},
+}
+
+func scan(root string) error {
+	appName, err := getAppName()
+	if err != nil {
+		return err
+	}
+
+	schema, err := schema.Read()
+	if err != nil {
+		return err
+	}
+
+	migrationRepo, err := migrationloaders.Load()
+	if err != nil {
+		return err
+	}
+
+	report, err := codescans.Scan(root, appName, schema, migrationRepo)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(report)
+	}
+
+	fmt.Printf("Found %d reference(s) to %d split(s)\n", len(report.References), len(schema.Splits)-len(report.Unreferenced))
+
+	if len(report.Unreferenced) != 0 {
+		fmt.Println("\nUnreferenced splits (safe to retire):")
+		for _, split := range report.Unreferenced {
+			fmt.Printf("  %s\n", split)
+		}
+	}
+
+	if len(report.Missing) != 0 {
+		fmt.Println("\nReferences to splits missing from the schema:")
+		for _, reference := range report.Missing {
+			status := "unknown"
+			if reference.Retired {
+				status = "retired"
+			}
+			fmt.Printf("  %s:%d: %s (%s)\n", reference.File, reference.Line, reference.Split, status)
+		}
+	}
+	return nil
+}
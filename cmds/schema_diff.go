@@ -0,0 +1,93 @@
+package cmds
+
+import (
+	"fmt"
+
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/Betterment/testtrack-cli/schemadiffs"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/servers"
+	"github.com/spf13/cobra"
+)
+
+var schemaDiffDoc = `
+Compares your local testtrack/schema.{json,yml} against the split registry of
+the TestTrack server at TESTTRACK_CLI_URL and reports drift: splits missing on
+either side, weight mismatches, and splits that are decided on one side but
+not the other.
+
+Only remote splits prefixed with your app name are considered, because the
+registry contains splits from every app.
+
+Exits with status 2 if drift is detected so CI can gate on it. Unlike 'sync',
+diff never changes your local schema.
+
+Example:
+
+testtrack schema diff --output json
+`
+
+func init() {
+	schemaCmd.AddCommand(schemaDiffCmd)
+}
+
+var schemaDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Report drift between schema.{json,yml} and the TestTrack server",
+	Long:  schemaDiffDoc,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		err := schemaDiff()
+		if _, ok := err.(*ExitStatusAwareError); ok {
+			cmd.SilenceUsage = true
+		}
+		return err
+	},
+}
+
+func schemaDiff() error {
+	appName, err := getAppName()
+	if err != nil {
+		return err
+	}
+
+	server, err := servers.New()
+	if err != nil {
+		return err
+	}
+
+	var splitRegistry serializers.RemoteRegistry
+	err = server.Get("api/v2/split_registry.json", &splitRegistry)
+	if err != nil {
+		return err
+	}
+
+	localSchema, err := schema.Read()
+	if err != nil {
+		return err
+	}
+
+	drifts := schemadiffs.Diff(localSchema, &splitRegistry, appName)
+
+	if jsonOutput() {
+		err := printJSON(map[string][]schemadiffs.Drift{"drift": drifts})
+		if err != nil {
+			return err
+		}
+	} else if len(drifts) == 0 {
+		fmt.Println("No drift detected")
+	} else {
+		for _, drift := range drifts {
+			fmt.Printf("%s: %s\n", drift.Split, drift.Description())
+		}
+	}
+
+	if len(drifts) != 0 {
+		return &ExitStatusAwareError{
+			description: fmt.Sprintf("schema drift detected in %d split(s)", len(drifts)),
+			exitStatus:  exitStatusDrift,
+			reported:    jsonOutput(),
+		}
+	}
+	return nil
+}
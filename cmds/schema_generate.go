@@ -38,7 +38,29 @@ var schemaGenerateCmd = &cobra.Command{
 	},
 }
 
+// generatedSchema is the JSON output of schema generate
+type generatedSchema struct {
+	SchemaVersion      string `json:"schema_version"`
+	Splits             int    `json:"splits"`
+	IdentifierTypes    int    `json:"identifier_types"`
+	RemoteKills        int    `json:"remote_kills"`
+	FeatureCompletions int    `json:"feature_completions"`
+}
+
 func schemaGenerate() error {
-	_, err := schema.Generate()
-	return err
+	generated, err := schema.Generate()
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(generatedSchema{
+			SchemaVersion:      generated.SchemaVersion,
+			Splits:             len(generated.Splits),
+			IdentifierTypes:    len(generated.IdentifierTypes),
+			RemoteKills:        len(generated.RemoteKills),
+			FeatureCompletions: len(generated.FeatureCompletions),
+		})
+	}
+	return nil
 }
@@ -0,0 +1,106 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/spf13/cobra"
+)
+
+var schemaLinksDoc = `
+Lists the schemas linked into your local testtrack server with 'testtrack
+schema link', including broken links to apps that have since moved or been
+uninstalled, which the server skips.
+
+Also lists conflicts: splits, remote kills and feature completions that more
+than one linked schema defines differently. The server uses the definition
+from the first linked schema in alphabetical order. Identical definitions
+aren't conflicts.
+
+Exits with status 2 if there are conflicts.
+
+Example:
+
+testtrack schema links
+`
+
+func init() {
+	schemaCmd.AddCommand(schemaLinksCmd)
+}
+
+var schemaLinksCmd = &cobra.Command{
+	Use:   "links",
+	Short: "List linked schemas and conflicts between them",
+	Long:  schemaLinksDoc,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		err := schemaLinks()
+		if _, ok := err.(*ExitStatusAwareError); ok {
+			cmd.SilenceUsage = true
+		}
+		return err
+	},
+}
+
+func schemaLinks() error {
+	links, err := schema.Links()
+	if err != nil {
+		return err
+	}
+
+	_, conflicts, err := schema.ReadMergedWithConflicts()
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		err := printJSON(map[string]interface{}{
+			"links":     links,
+			"conflicts": conflicts,
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		if len(links) == 0 {
+			fmt.Println("No linked schemas")
+		} else {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "SCHEMA\tTARGET\tSTATUS")
+			for _, link := range links {
+				status := "ok"
+				if link.Broken {
+					status = "broken"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", filepath.Base(link.Path), optionalString(&link.Target), status)
+			}
+			err := w.Flush()
+			if err != nil {
+				return err
+			}
+		}
+		if len(conflicts) != 0 {
+			fmt.Println("\nConflicts:")
+			for _, conflict := range conflicts {
+				files := make([]string, 0, len(conflict.Files))
+				for _, file := range conflict.Files {
+					files = append(files, filepath.Base(file))
+				}
+				fmt.Printf("  %s %s in %s: %s\n", conflict.Kind, conflict.Name, strings.Join(files, ", "), conflict.Detail)
+			}
+		}
+	}
+
+	if len(conflicts) != 0 {
+		return &ExitStatusAwareError{
+			description: fmt.Sprintf("%d conflict(s) between linked schemas", len(conflicts)),
+			exitStatus:  exitStatusDrift,
+			reported:    jsonOutput(),
+		}
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package cmds
 
 import (
+	"os"
+
 	"github.com/Betterment/testtrack-cli/fakeserver"
 	"github.com/spf13/cobra"
 )
@@ -8,14 +10,49 @@ import (
 var serverDoc = `
 Run a fake TestTrack server for local development, backed by schema.{json,yml} files
 and nonsense.
+
+The server keeps linked schemas and assignments in memory. It checks
+~/.testtrack/schemas, assignments.yml and visitors.yml for changes every second,
+reloads whatever changed and logs a line for each reloaded file, so schema
+changes and 'testtrack assign' take effect without a restart.
+
+By default visitors only see the variants they've been assigned via overrides,
+and clients fall back to their own defaults for everything else. Pass
+--weighted-assignments to have the server assign visitors to variants according
+to each split's weights instead, so local development sees a realistic
+distribution. Weighted assignments are stable per visitor and are cleared by
+'testtrack unassign --all'.
+
+App build routes behave like production: remote kills override their split
+for app versions from first_bad_version up to fixed_version, and feature gates
+are forced to false for app versions before their feature completion. Only the
+splits prefixed with the app name in the route are affected, and app versions
+that can't be parsed are served the schema as it is.
+
+Browse to /admin on the server to pick variants for every split, for all
+visitors or a single one, and to reset all overrides. The page is built on
+JSON routes you can script against too: GET /admin/schema, GET and POST
+/admin/assignments, and POST /admin/reset.
+
+Assignment events, identifier posts and assignment override posts are recorded
+in an event log so tests can check what clients reported. List them with
+'testtrack events' or GET /admin/events. POST /admin/reset clears the log.
+
+Pass --record file.jsonl to append every request the server handles and its
+response to a file, which 'testtrack replay' can re-issue against another
+server to compare responses.
 `
 
 var port int
+var weightedAssignments bool
+var recordPath string
 
 const defaultPort = 8297
 
 func init() {
 	serverCmd.Flags().IntVarP(&port, "port", "p", defaultPort, "Port to listen on")
+	serverCmd.Flags().BoolVar(&weightedAssignments, "weighted-assignments", false, "Assign visitors without overrides according to split weights")
+	serverCmd.Flags().StringVar(&recordPath, "record", "", "Append every request and response to this JSON lines file for 'testtrack replay'")
 	rootCmd.AddCommand(serverCmd)
 }
 
@@ -25,7 +62,18 @@ var serverCmd = &cobra.Command{
 	Long:  serverDoc,
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fakeserver.Start(port)
+		options := fakeserver.Options{
+			WeightedAssignments: weightedAssignments,
+		}
+		if recordPath != "" {
+			file, err := os.OpenFile(recordPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			options.Record = file
+		}
+		fakeserver.Start(port, options)
 		return nil
 	},
 }
@@ -0,0 +1,169 @@
+package cmds
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Betterment/testtrack-cli/histories"
+	"github.com/Betterment/testtrack-cli/migrationloaders"
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/splits"
+	"github.com/spf13/cobra"
+)
+
+var showDoc = `
+Shows a split's weights and decision status, the remote kills and feature
+completion affecting it, and the history of migrations that touched it.
+
+The split name is prefixed with your app name unless it's already prefixed or
+you pass --no-prefix. Retired splits can still be shown, in which case only
+their history is printed.
+
+Example:
+
+testtrack show my_fancy_experiment
+`
+
+func init() {
+	showCmd.Flags().BoolVar(&noPrefix, "no-prefix", false, "Don't prefix split with app_name (supports legacy splits)")
+	rootCmd.AddCommand(showCmd)
+}
+
+var showCmd = &cobra.Command{
+	Use:   "show split_name",
+	Short: "Show a split and its history",
+	Long:  showDoc,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return show(args[0])
+	},
+}
+
+// splitDetail is the JSON output of show
+type splitDetail struct {
+	Name               string                          `json:"name"`
+	Split              *serializers.SchemaSplit        `json:"split"`
+	RemoteKills        []serializers.RemoteKill        `json:"remote_kills"`
+	FeatureCompletions []serializers.FeatureCompletion `json:"feature_completions"`
+	History            []histories.Entry               `json:"history"`
+}
+
+// prefixSplitName prefixes a split name with the app name unless it's
+// already prefixed or --no-prefix was passed
+func prefixSplitName(name string) (string, error) {
+	if noPrefix || strings.Contains(name, ".") {
+		return name, nil
+	}
+	appName, err := getAppName()
+	if err != nil {
+		return "", err
+	}
+	return appName + "." + name, nil
+}
+
+func show(name string) error {
+	name, err := prefixSplitName(name)
+	if err != nil {
+		return err
+	}
+
+	schema, err := schema.Read()
+	if err != nil {
+		return err
+	}
+
+	migrationRepo, err := migrationloaders.Load()
+	if err != nil {
+		return err
+	}
+
+	detail := splitDetail{
+		Name:               name,
+		RemoteKills:        []serializers.RemoteKill{},
+		FeatureCompletions: []serializers.FeatureCompletion{},
+		History:            histories.ForSplit(name, migrationRepo),
+	}
+	for i := range schema.Splits {
+		if schema.Splits[i].Name == name {
+			detail.Split = &schema.Splits[i]
+		}
+	}
+	for _, remoteKill := range schema.RemoteKills {
+		if remoteKill.Split == name {
+			detail.RemoteKills = append(detail.RemoteKills, remoteKill)
+		}
+	}
+	for _, featureCompletion := range schema.FeatureCompletions {
+		if featureCompletion.FeatureGate == name {
+			detail.FeatureCompletions = append(detail.FeatureCompletions, featureCompletion)
+		}
+	}
+
+	if detail.Split == nil && len(detail.History) == 0 {
+		return fmt.Errorf("split %s not found in schema or migrations", name)
+	}
+
+	if jsonOutput() {
+		return printJSON(detail)
+	}
+
+	fmt.Println(name)
+	if detail.Split == nil {
+		fmt.Println("  retired")
+	} else {
+		weights := splits.Weights(detail.Split.Weights)
+		fmt.Printf("  weights: %s\n", weights.String())
+		if detail.Split.Decided {
+			fmt.Println("  decided: yes")
+		} else {
+			fmt.Println("  decided: no")
+		}
+		if detail.Split.Owner != "" {
+			fmt.Printf("  owner: %s\n", detail.Split.Owner)
+		}
+		if detail.Split.Details != nil {
+			printSplitDetails(detail.Split.Details)
+		}
+	}
+	for _, remoteKill := range detail.RemoteKills {
+		fmt.Printf("  remote kill %s: overrides to %s from version %s", remoteKill.Reason, optionalString(remoteKill.OverrideTo), optionalString(remoteKill.FirstBadVersion))
+		if remoteKill.FixedVersion != nil && *remoteKill.FixedVersion != "" {
+			fmt.Printf(" until %s", *remoteKill.FixedVersion)
+		}
+		fmt.Println()
+	}
+	for _, featureCompletion := range detail.FeatureCompletions {
+		fmt.Printf("  feature completed in version %s\n", optionalString(featureCompletion.Version))
+	}
+
+	fmt.Println("\nHistory:")
+	for _, entry := range detail.History {
+		fmt.Printf("  %s %s: %s\n", entry.Version, entry.Action, entry.Description)
+	}
+	return nil
+}
+
+func printSplitDetails(details *serializers.SplitDetails) {
+	for _, field := range []struct{ label, value string }{
+		{"hypothesis", details.Hypothesis},
+		{"description", details.Description},
+		{"assignment criteria", details.AssignmentCriteria},
+		{"location", details.Location},
+		{"platform", details.Platform},
+	} {
+		if field.value != "" {
+			fmt.Printf("  %s: %s\n", field.label, field.value)
+		}
+	}
+	for _, variantDetail := range details.VariantDetails {
+		fmt.Printf("  variant %s:", variantDetail.Name)
+		if variantDetail.Description != "" {
+			fmt.Printf(" %s", variantDetail.Description)
+		}
+		if variantDetail.ScreenshotURL != "" {
+			fmt.Printf(" (%s)", variantDetail.ScreenshotURL)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,131 @@
+package cmds
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/spf13/cobra"
+)
+
+var splitDetailsDoc = `
+Splits can be documented with --hypothesis, --description,
+--assignment_criteria, --location and --platform, and each variant with
+--variant_description and --variant_screenshot_url, e.g.:
+
+--variant_description "treatment=Shows the new checkout flow"
+
+Documentation is recorded in the migration and schema, and served by the fake
+server's split details endpoint. Flags that are passed replace the matching
+parts of a split's existing documentation and the rest is kept; omit them all
+to leave it unchanged.
+`
+
+var splitDetailsHypothesis, splitDetailsDescription, splitDetailsAssignmentCriteria, splitDetailsLocation, splitDetailsPlatform string
+var splitDetailsVariantDescriptions, splitDetailsVariantScreenshotURLs []string
+
+func addSplitDetailsFlags(cmd *cobra.Command) {
+	cmd.Long += splitDetailsDoc
+	cmd.Flags().StringVar(&splitDetailsHypothesis, "hypothesis", "", "What you expect the split to prove")
+	cmd.Flags().StringVar(&splitDetailsDescription, "description", "", "What the split does")
+	cmd.Flags().StringVar(&splitDetailsAssignmentCriteria, "assignment_criteria", "", "Who is assigned to the split")
+	cmd.Flags().StringVar(&splitDetailsLocation, "location", "", "Where in the app the split is seen")
+	cmd.Flags().StringVar(&splitDetailsPlatform, "platform", "", "Platform the split runs on")
+	cmd.Flags().StringArrayVar(&splitDetailsVariantDescriptions, "variant_description", nil, "Description of a variant as variant=description (repeatable)")
+	cmd.Flags().StringArrayVar(&splitDetailsVariantScreenshotURLs, "variant_screenshot_url", nil, "Screenshot of a variant as variant=url (repeatable)")
+}
+
+// splitDetailsFromFlags returns the split's current documentation with the
+// flags passed on the command line applied over it, or nil if none were passed
+func splitDetailsFromFlags(cmd *cobra.Command, schema *serializers.Schema, name string) (*serializers.SplitDetails, error) {
+	changed := false
+	for _, flag := range []string{"hypothesis", "description", "assignment_criteria", "location", "platform", "variant_description", "variant_screenshot_url"} {
+		changed = changed || cmd.Flags().Changed(flag)
+	}
+	if !changed {
+		return nil, nil
+	}
+
+	descriptions, err := parseVariantFlags("variant_description", splitDetailsVariantDescriptions)
+	if err != nil {
+		return nil, err
+	}
+	screenshotURLs, err := parseVariantFlags("variant_screenshot_url", splitDetailsVariantScreenshotURLs)
+	if err != nil {
+		return nil, err
+	}
+	for variant, screenshotURL := range screenshotURLs {
+		parsed, err := url.ParseRequestURI(screenshotURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return nil, fmt.Errorf("variant_screenshot_url for %s '%s' must be an http(s) URL", variant, screenshotURL)
+		}
+	}
+
+	details := &serializers.SplitDetails{}
+	variantDetails := map[string]serializers.VariantDetail{}
+	for _, schemaSplit := range schema.Splits {
+		if schemaSplit.Name == name && schemaSplit.Details != nil {
+			*details = *schemaSplit.Details
+			for _, variantDetail := range schemaSplit.Details.VariantDetails {
+				variantDetails[variantDetail.Name] = variantDetail
+			}
+		}
+	}
+
+	for flag, field := range map[string]*string{
+		"hypothesis":          &details.Hypothesis,
+		"description":         &details.Description,
+		"assignment_criteria": &details.AssignmentCriteria,
+		"location":            &details.Location,
+		"platform":            &details.Platform,
+	} {
+		if cmd.Flags().Changed(flag) {
+			*field, _ = cmd.Flags().GetString(flag)
+		}
+	}
+	for variant, description := range descriptions {
+		variantDetail := variantDetails[variant]
+		variantDetail.Name = variant
+		variantDetail.Description = description
+		variantDetails[variant] = variantDetail
+	}
+	for variant, screenshotURL := range screenshotURLs {
+		variantDetail := variantDetails[variant]
+		variantDetail.Name = variant
+		variantDetail.ScreenshotURL = screenshotURL
+		variantDetails[variant] = variantDetail
+	}
+
+	variants := []string{}
+	for variant := range variantDetails {
+		variants = append(variants, variant)
+	}
+	sort.Strings(variants)
+	details.VariantDetails = nil
+	for _, variant := range variants {
+		details.VariantDetails = append(details.VariantDetails, variantDetails[variant])
+	}
+
+	if reflect.DeepEqual(*details, serializers.SplitDetails{}) {
+		return nil, nil
+	}
+	return details, nil
+}
+
+func parseVariantFlags(flagName string, values []string) (map[string]string, error) {
+	result := make(map[string]string, len(values))
+	for _, value := range values {
+		variant, detail, ok := strings.Cut(value, "=")
+		if !ok || variant == "" {
+			return nil, fmt.Errorf("%s '%s' must be formatted variant=value", flagName, value)
+		}
+		if _, ok := result[variant]; ok {
+			return nil, fmt.Errorf("%s given more than once for variant %s", flagName, variant)
+		}
+		result[variant] = detail
+	}
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package cmds
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/migrationloaders"
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/Betterment/testtrack-cli/stalefindings"
+	"github.com/spf13/cobra"
+)
+
+var staleDoc = `
+Reports splits and remote kills that have probably outlived their usefulness,
+aged by the timestamps of the migrations that put them in their current state:
+
+* experiments that have been running undecided for longer than --days
+* splits that were decided longer than --days ago but never retired
+* feature gates that have been enabled for 100% of visitors for longer than
+  --days
+* remote kills with a fixed_version that haven't changed in longer than --days
+
+Each finding includes the command that would clean it up. Experiments need you
+to choose the winning variant before deciding them.
+
+Example:
+
+testtrack stale --days 60
+`
+
+var staleDays int
+
+func init() {
+	staleCmd.Flags().IntVar(&staleDays, "days", 90, "Age in days after which a resource is considered stale")
+	rootCmd.AddCommand(staleCmd)
+}
+
+var staleCmd = &cobra.Command{
+	Use:   "stale",
+	Short: "Report stale splits and remote kills with cleanup commands",
+	Long:  staleDoc,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return stale(staleDays)
+	},
+}
+
+func stale(days int) error {
+	if days < 0 {
+		return fmt.Errorf("days %d must not be negative", days)
+	}
+
+	appName, err := getAppName()
+	if err != nil {
+		return err
+	}
+
+	schema, err := schema.Read()
+	if err != nil {
+		return err
+	}
+
+	migrationRepo, err := migrationloaders.Load()
+	if err != nil {
+		return err
+	}
+
+	findings := stalefindings.Find(schema, migrationRepo, appName, days, time.Now().UTC())
+
+	if jsonOutput() {
+		return printJSON(map[string][]stalefindings.Finding{"stale": findings})
+	}
+
+	if len(findings) == 0 {
+		fmt.Printf("Nothing stale for more than %d days\n", days)
+		return nil
+	}
+	for _, finding := range findings {
+		fmt.Printf("%s (%s): %s\n", finding.Resource, finding.Kind, finding.Message)
+		if finding.Command != "" {
+			fmt.Printf("  %s\n", finding.Command)
+		}
+	}
+	return nil
+}
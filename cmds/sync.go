@@ -1,6 +1,8 @@
 package cmds
 
 import (
+	"reflect"
+
 	"github.com/Betterment/testtrack-cli/schema"
 	"github.com/Betterment/testtrack-cli/serializers"
 	"github.com/Betterment/testtrack-cli/servers"
@@ -26,6 +28,13 @@ var syncCommand = &cobra.Command{
 	},
 }
 
+// syncedSplit describes a local split whose weights were changed by a sync
+type syncedSplit struct {
+	Name            string         `json:"name"`
+	PreviousWeights map[string]int `json:"previous_weights"`
+	Weights         map[string]int `json:"weights"`
+}
+
 // Sync synchronizes the local schema TestTrack assignments with the remote production TestTrack assignments.
 func Sync() error {
 	server, err := servers.New()
@@ -44,10 +53,18 @@ func Sync() error {
 		return err
 	}
 
+	changed := []syncedSplit{}
 	for ind, localSplit := range localSchema.Splits {
 		remoteSplit, exists := splitRegistry.Splits[localSplit.Name]
 		if exists {
 			remoteWeights := splits.Weights(remoteSplit.Weights)
+			if !reflect.DeepEqual(localSplit.Weights, remoteSplit.Weights) {
+				changed = append(changed, syncedSplit{
+					Name:            localSplit.Name,
+					PreviousWeights: localSplit.Weights,
+					Weights:         remoteWeights,
+				})
+			}
 			localSchema.Splits[ind].Weights = remoteWeights
 		}
 	}
@@ -56,5 +73,8 @@ func Sync() error {
 		return err
 	}
 
+	if jsonOutput() {
+		return printJSON(map[string][]syncedSplit{"changed_splits": changed})
+	}
 	return nil
 }
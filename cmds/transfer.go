@@ -0,0 +1,21 @@
+package cmds
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var transferDoc = `
+Transfer a resource to a new owner in the local schema and write a migration
+file so the change can be applied in other environments via the build/deploy
+pipeline.
+`
+
+func init() {
+	rootCmd.AddCommand(transferCmd)
+}
+
+var transferCmd = &cobra.Command{
+	Use:   "transfer",
+	Short: "Transfer ownership of a TestTrack resource",
+	Long:  transferDoc,
+}
@@ -0,0 +1,78 @@
+package cmds
+
+import (
+	"github.com/Betterment/testtrack-cli/ownershiptransfers"
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/Betterment/testtrack-cli/validations"
+	"github.com/spf13/cobra"
+)
+
+var transferOwnershipDoc = `
+Transfers a split (a feature gate or experiment) to a new owner, e.g. when a
+team is reorganized. The owner must be defined in testtrack/owners.yml.
+
+Ownership is local to your project: the migration is recorded in the schema
+and in your migration history, but nothing is posted to the TestTrack server.
+
+Example:
+
+testtrack transfer ownership my_fancy_experiment --owner checkout_team
+`
+
+var transferOwnershipOwner string
+
+func init() {
+	transferOwnershipCmd.Flags().StringVar(&transferOwnershipOwner, "owner", "", "Who will own this split going forward")
+	transferOwnershipCmd.MarkFlagRequired("owner")
+	transferOwnershipCmd.Flags().BoolVar(&noPrefix, "no-prefix", false, "Don't prefix split with app_name (supports legacy splits)")
+	transferCmd.AddCommand(transferOwnershipCmd)
+}
+
+var transferOwnershipCmd = &cobra.Command{
+	Use:   "ownership split_name",
+	Short: "Transfer a split to a new owner",
+	Long:  transferOwnershipDoc,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return transferOwnership(args[0], transferOwnershipOwner)
+	},
+}
+
+func transferOwnership(name, owner string) error {
+	schema, err := schema.Read()
+	if err != nil {
+		return err
+	}
+
+	err = validations.NonPrefixedSplit("name", &name)
+	if err != nil {
+		return err
+	}
+
+	appName, err := getAppName()
+	if err != nil {
+		return err
+	}
+
+	err = validations.ValidateOwnerName(owner)
+	if err != nil {
+		return err
+	}
+
+	err = validations.AutoPrefixAndValidateSplit("name", &name, appName, schema, noPrefix, false)
+	if err != nil {
+		return err
+	}
+
+	ownershipTransfer, err := ownershiptransfers.New(&name, &owner)
+	if err != nil {
+		return err
+	}
+
+	err = createMigration(ownershipTransfer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -13,7 +13,8 @@ import (
 var unassignDoc = `
 Removes an assignment override for a split in the fake TestTrack server.
 
-This command can also be used to reset all overrides.
+This command can also be used to reset all overrides, including overrides
+that clients made for individual visitors.
 
 Example:
 
@@ -61,6 +62,15 @@ func runUnassignAll() error {
 	if err != nil {
 		return err
 	}
+	visitors, err := fakeassignments.ReadVisitors()
+	if err != nil {
+		return err
+	}
+	visitors.Assignments = make(map[string]map[string]string)
+	err = fakeassignments.WriteVisitors(visitors)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -0,0 +1,59 @@
+package cmds
+
+import (
+	"fmt"
+
+	"github.com/Betterment/testtrack-cli/formatupgrades"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/spf13/cobra"
+)
+
+var upgradeFormatDoc = `
+Upgrades migration files in testtrack/migrate and your schema.{json,yml} that
+were written by an older version of testtrack to the current serializer
+version, so that newer features such as richer split metadata can be used.
+Migration files only have their serializer_version line bumped unless their
+format changed, and files already at the current version are left untouched.
+
+Everyone working on the project needs a version of testtrack that supports the
+new format, because older versions can't read it safely.
+
+Example:
+
+testtrack upgrade_format
+`
+
+func init() {
+	rootCmd.AddCommand(upgradeFormatCmd)
+}
+
+var upgradeFormatCmd = &cobra.Command{
+	Use:   "upgrade_format",
+	Short: "Upgrade migration files and schema to the current format",
+	Long:  upgradeFormatDoc,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return upgradeFormat()
+	},
+}
+
+func upgradeFormat() error {
+	result, err := formatupgrades.Upgrade()
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(result)
+	}
+
+	if len(result.Migrations) == 0 && result.Schema == "" {
+		fmt.Printf("Already at serializer_version %d\n", serializers.SerializerVersion)
+		return nil
+	}
+	fmt.Printf("Upgraded %d migration(s) to serializer_version %d\n", len(result.Migrations), serializers.SerializerVersion)
+	if result.Schema != "" {
+		fmt.Printf("Upgraded %s to serializer_version %d\n", result.Schema, serializers.SerializerVersion)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package cmds
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/expirations"
+	"github.com/Betterment/testtrack-cli/migrationloaders"
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/Betterment/testtrack-cli/schemachecks"
+	"github.com/spf13/cobra"
+)
+
+var validateDoc = `
+Checks the consistency of your project's TestTrack configuration as a whole.
+Every migration in testtrack/migrate is replayed into a fresh schema and
+compared against the committed testtrack/schema.{json,yml}, and then
+references between resources are checked:
+
+* remote kills and feature completions must refer to splits that exist or
+  once existed (splits prefixed with other apps' names are skipped)
+* remote kill override_to variants must be variants of their split
+* split owners must be listed in testtrack/owners.yml
+* remote kills and feature completions must not be past their expires_at date
+  (run 'testtrack expire' to destroy them)
+
+Exits with status 2 if problems are found so CI can gate on it.
+
+Example:
+
+testtrack validate --output json
+`
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check schema.{json,yml} and migrations for consistency",
+	Long:  validateDoc,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		err := validate()
+		if _, ok := err.(*ExitStatusAwareError); ok {
+			cmd.SilenceUsage = true
+		}
+		return err
+	},
+}
+
+func validate() error {
+	appName, err := getAppName()
+	if err != nil {
+		return err
+	}
+
+	committedSchema, err := schema.ReadCommitted()
+	if err != nil {
+		return err
+	}
+
+	migrationRepo, err := migrationloaders.Load()
+	if err != nil {
+		return err
+	}
+
+	replayedSchema, err := schema.Replay(migrationRepo, "")
+	if err != nil {
+		return err
+	}
+
+	problems := schemachecks.Check(committedSchema, replayedSchema, migrationRepo, appName)
+
+	expired, err := expirations.Find(replayedSchema, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	for _, expiration := range expired {
+		problems = append(problems, schemachecks.Problem{
+			Code:     schemachecks.Expired,
+			Resource: expiration.Kind + ":" + expiration.Resource,
+			Message:  fmt.Sprintf("expired on %s; run 'testtrack expire' to destroy it", expiration.ExpiresAt),
+		})
+	}
+
+	if jsonOutput() {
+		err := printJSON(map[string][]schemachecks.Problem{"problems": problems})
+		if err != nil {
+			return err
+		}
+	} else if len(problems) == 0 {
+		fmt.Println("No problems found")
+	} else {
+		for _, problem := range problems {
+			fmt.Printf("%s %s: %s\n", problem.Code, problem.Resource, problem.Message)
+		}
+	}
+
+	if len(problems) != 0 {
+		return &ExitStatusAwareError{
+			description: fmt.Sprintf("found %d problem(s)", len(problems)),
+			exitStatus:  exitStatusDrift,
+			reported:    jsonOutput(),
+		}
+	}
+	return nil
+}
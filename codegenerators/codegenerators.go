@@ -0,0 +1,243 @@
+package codegenerators
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/splits"
+)
+
+// Languages lists the languages code can be generated for
+var Languages = []string{"go", "kotlin", "ruby", "swift", "ts"}
+
+var defaultPackages = map[string]string{
+	"go":     "testtrack",
+	"kotlin": "",
+	"ruby":   "TestTrackSplits",
+	"swift":  "",
+	"ts":     "",
+}
+
+// Split is the template model of a split
+type Split struct {
+	// Name is the full split name
+	Name string
+	// Words are the words of the split name used to build identifiers, without
+	// the app prefix if the split belongs to the app
+	Words       []string
+	FeatureGate bool
+	Variants    []Variant
+}
+
+// Variant is the template model of a split variant
+type Variant struct {
+	Name  string
+	Words []string
+}
+
+type model struct {
+	Package string
+	Splits  []Split
+}
+
+// FeatureGates returns the splits that are feature gates
+func (m model) FeatureGates() []Split {
+	result := []Split{}
+	for _, split := range m.Splits {
+		if split.FeatureGate {
+			result = append(result, split)
+		}
+	}
+	return result
+}
+
+// Experiments returns the splits that aren't feature gates
+func (m model) Experiments() []Split {
+	result := []Split{}
+	for _, split := range m.Splits {
+		if !split.FeatureGate {
+			result = append(result, split)
+		}
+	}
+	return result
+}
+
+// Generate renders constants for a schema's splits and variants, and boolean
+// accessors for its feature gates, in the given language. packageName is the
+// Go or Kotlin package or Ruby module to generate into, and defaults per
+// language if empty.
+func Generate(lang string, schema *serializers.Schema, appName, packageName string) ([]byte, error) {
+	tmpl, ok := templates[lang]
+	if !ok {
+		return nil, fmt.Errorf("lang %s must be one of %s", lang, strings.Join(Languages, ", "))
+	}
+	if packageName == "" {
+		packageName = defaultPackages[lang]
+	}
+
+	m, err := buildModel(schema, appName, packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	err = template.Must(template.New(lang).Funcs(funcs).Parse(tmpl)).Execute(&out, m)
+	if err != nil {
+		return nil, err
+	}
+
+	if lang == "go" {
+		return format.Source(out.Bytes())
+	}
+	return out.Bytes(), nil
+}
+
+// reservedIdentifiers are generated whatever the schema, in some language
+var reservedIdentifiers = []string{"FeatureGates", "Split", "SplitName", "Splits"}
+
+// topLevelIdentifiers tracks the identifiers generated at the top level of a
+// file, which share one namespace in Go
+type topLevelIdentifiers map[string]string
+
+func (t topLevelIdentifiers) claim(identifier, source string) error {
+	if other, ok := t[identifier]; ok {
+		return fmt.Errorf("%s and %s would generate the same identifier %s", other, source, identifier)
+	}
+	t[identifier] = source
+	return nil
+}
+
+func buildModel(schema *serializers.Schema, appName, packageName string) (model, error) {
+	m := model{Package: packageName}
+	identifiers := make(map[string]string)
+	topLevel := make(topLevelIdentifiers)
+	for _, identifier := range reservedIdentifiers {
+		topLevel[identifier] = "the generated " + identifier + " type"
+	}
+	for _, schemaSplit := range schema.Splits {
+		split := Split{
+			Name:        schemaSplit.Name,
+			Words:       words(strings.TrimPrefix(schemaSplit.Name, appName+".")),
+			FeatureGate: splits.IsFeatureGateFromName(schemaSplit.Name),
+		}
+		identifier := pascal(split.Words)
+		if other, ok := identifiers[identifier]; ok {
+			return model{}, fmt.Errorf("splits %s and %s would generate the same identifier %s", other, split.Name, identifier)
+		}
+		identifiers[identifier] = split.Name
+		err := topLevel.claim("Split"+identifier, "split "+split.Name)
+		if err != nil {
+			return model{}, err
+		}
+		if !split.FeatureGate {
+			err = topLevel.claim(identifier+"Variant", "the variant type of "+split.Name)
+			if err != nil {
+				return model{}, err
+			}
+			err = topLevel.claim(identifier+"Variants", "the variants of "+split.Name)
+			if err != nil {
+				return model{}, err
+			}
+		}
+
+		variantNames := make([]string, 0, len(schemaSplit.Weights))
+		for variant := range schemaSplit.Weights {
+			variantNames = append(variantNames, variant)
+		}
+		sort.Strings(variantNames)
+		variantIdentifiers := make(map[string]string)
+		for _, name := range variantNames {
+			variant := Variant{Name: name, Words: words(name)}
+			variantIdentifier := pascal(variant.Words)
+			if other, ok := variantIdentifiers[variantIdentifier]; ok {
+				return model{}, fmt.Errorf("variants %s and %s of %s would generate the same identifier %s", other, name, split.Name, variantIdentifier)
+			}
+			variantIdentifiers[variantIdentifier] = name
+			if !split.FeatureGate {
+				err = topLevel.claim(identifier+variantIdentifier, fmt.Sprintf("variant %s of %s", name, split.Name))
+				if err != nil {
+					return model{}, err
+				}
+			}
+			split.Variants = append(split.Variants, variant)
+		}
+
+		m.Splits = append(m.Splits, split)
+	}
+	sort.Slice(m.Splits, func(i, j int) bool {
+		return m.Splits[i].Name < m.Splits[j].Name
+	})
+	return m, nil
+}
+
+// words splits a name into lowercase words on anything that isn't a letter
+// or digit, prepending a word if the name would start with a digit
+func words(name string) []string {
+	result := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(result) == 0 || unicode.IsDigit(rune(result[0][0])) {
+		result = append([]string{"v"}, result...)
+	}
+	return result
+}
+
+func pascal(words []string) string {
+	var b strings.Builder
+	for _, word := range words {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+func camel(words []string) string {
+	identifier := pascal(words)
+	return strings.ToLower(identifier[:1]) + identifier[1:]
+}
+
+func screamingSnake(words []string) string {
+	return strings.ToUpper(strings.Join(words, "_"))
+}
+
+func snake(words []string) string {
+	return strings.Join(words, "_")
+}
+
+var swiftKeywords = map[string]bool{
+	"case": true, "class": true, "default": true, "enum": true, "false": true,
+	"func": true, "import": true, "in": true, "init": true, "internal": true,
+	"let": true, "nil": true, "private": true, "protocol": true, "public": true,
+	"return": true, "self": true, "static": true, "struct": true, "switch": true,
+	"true": true, "var": true, "where": true, "while": true,
+}
+
+// swiftCase returns a camelCase identifier, escaped if it's a keyword
+func swiftCase(words []string) string {
+	identifier := camel(words)
+	if swiftKeywords[identifier] {
+		return "`" + identifier + "`"
+	}
+	return identifier
+}
+
+// rubyQuote returns a single-quoted Ruby string literal
+func rubyQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+var funcs = template.FuncMap{
+	"quote":          strconv.Quote,
+	"rubyQuote":      rubyQuote,
+	"pascal":         pascal,
+	"camel":          camel,
+	"screamingSnake": screamingSnake,
+	"snake":          snake,
+	"swiftCase":      swiftCase,
+}
@@ -0,0 +1,93 @@
+package codegenerators_test
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/Betterment/testtrack-cli/codegenerators"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/stretchr/testify/require"
+)
+
+var schema = &serializers.Schema{
+	Splits: []serializers.SchemaSplit{
+		{Name: "my_app.fancy_experiment", Weights: map[string]int{"control": 50, "treatment_a": 50}},
+		{Name: "my_app.banner_enabled", Weights: map[string]int{"false": 100, "true": 0}},
+		{Name: "legacy_experiment", Weights: map[string]int{"default": 50, "2x": 50}},
+	},
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("it generates go that type checks", func(t *testing.T) {
+		out, err := codegenerators.Generate("go", schema, "my_app", "flags")
+		require.NoError(t, err)
+
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, "flags.go", out, 0)
+		require.NoError(t, err)
+		config := types.Config{Importer: importer.Default()}
+		pkg, err := config.Check("flags", fset, []*ast.File{file}, nil)
+		require.NoError(t, err)
+
+		for _, name := range []string{"SplitFancyExperiment", "SplitBannerEnabled", "SplitLegacyExperiment", "FancyExperimentTreatmentA", "LegacyExperimentV2x", "FeatureGates"} {
+			require.NotNil(t, pkg.Scope().Lookup(name), name)
+		}
+	})
+
+	t.Run("it generates constants and feature gate accessors for each language", func(t *testing.T) {
+		expectations := map[string][]string{
+			"kotlin": {`FANCY_EXPERIMENT("my_app.fancy_experiment")`, `TREATMENT_A("treatment_a")`, `val bannerEnabled: Boolean`},
+			"ruby":   {`FANCY_EXPERIMENT = 'my_app.fancy_experiment'`, `TREATMENT_A = 'treatment_a'`, `def banner_enabled?`},
+			"swift":  {`case fancyExperiment = "my_app.fancy_experiment"`, "case `default` = \"default\"", `public var bannerEnabled: Bool`},
+			"ts":     {`FancyExperiment: "my_app.fancy_experiment"`, `TreatmentA: "treatment_a"`, `get bannerEnabled(): boolean`},
+		}
+		for lang, snippets := range expectations {
+			out, err := codegenerators.Generate(lang, schema, "my_app", "")
+			require.NoError(t, err)
+			for _, snippet := range snippets {
+				require.Contains(t, string(out), snippet, lang)
+			}
+		}
+	})
+
+	t.Run("it rejects unknown languages", func(t *testing.T) {
+		_, err := codegenerators.Generate("cobol", schema, "my_app", "")
+		require.EqualError(t, err, "lang cobol must be one of go, kotlin, ruby, swift, ts")
+	})
+
+	t.Run("it rejects splits that would generate the same identifier", func(t *testing.T) {
+		conflicting := &serializers.Schema{
+			Splits: []serializers.SchemaSplit{
+				{Name: "my_app.banner_enabled", Weights: map[string]int{"false": 100, "true": 0}},
+				{Name: "banner_enabled", Weights: map[string]int{"false": 100, "true": 0}},
+			},
+		}
+		_, err := codegenerators.Generate("ts", conflicting, "my_app", "")
+		require.EqualError(t, err, "splits my_app.banner_enabled and banner_enabled would generate the same identifier BannerEnabled")
+	})
+
+	t.Run("it rejects identifiers that would collide across splits", func(t *testing.T) {
+		conflicting := &serializers.Schema{
+			Splits: []serializers.SchemaSplit{
+				{Name: "my_app.foo_bar", Weights: map[string]int{"baz": 50, "control": 50}},
+				{Name: "my_app.foo", Weights: map[string]int{"bar_baz": 50, "control": 50}},
+			},
+		}
+		_, err := codegenerators.Generate("go", conflicting, "my_app", "")
+		require.EqualError(t, err, "variant baz of my_app.foo_bar and variant bar_baz of my_app.foo would generate the same identifier FooBarBaz")
+	})
+
+	t.Run("it rejects variants that would collide with a type", func(t *testing.T) {
+		conflicting := &serializers.Schema{
+			Splits: []serializers.SchemaSplit{
+				{Name: "my_app.fancy_experiment", Weights: map[string]int{"control": 50, "variant": 50}},
+			},
+		}
+		_, err := codegenerators.Generate("go", conflicting, "my_app", "")
+		require.EqualError(t, err, "the variant type of my_app.fancy_experiment and variant variant of my_app.fancy_experiment would generate the same identifier FancyExperimentVariant")
+	})
+}
@@ -0,0 +1,149 @@
+package codegenerators
+
+const header = "Code generated by testtrack codegen. DO NOT EDIT."
+
+var templates = map[string]string{
+	"go": `// ` + header + `
+
+package {{ .Package }}
+
+// Split is the name of a TestTrack split
+type Split string
+
+// Splits in the schema
+const (
+{{- range .Splits }}
+	Split{{ pascal .Words }} Split = {{ quote .Name }}
+{{- end }}
+)
+{{ range .Experiments }}{{ $split := . }}
+// {{ pascal .Words }}Variant is a variant of {{ .Name }}
+type {{ pascal .Words }}Variant string
+
+// Variants of {{ .Name }}
+const (
+{{- range .Variants }}
+	{{ pascal $split.Words }}{{ pascal .Words }} {{ pascal $split.Words }}Variant = {{ quote .Name }}
+{{- end }}
+)
+{{ end }}
+// FeatureGates provides boolean accessors for feature gates, given a function
+// returning a split's assigned variant
+type FeatureGates struct {
+	VariantFor func(split Split) string
+}
+{{ range .FeatureGates }}
+// {{ pascal .Words }} returns whether {{ .Name }} is enabled
+func (f FeatureGates) {{ pascal .Words }}() bool {
+	return f.VariantFor(Split{{ pascal .Words }}) == "true"
+}
+{{ end }}`,
+
+	"kotlin": `// ` + header + `
+{{ if .Package }}
+package {{ .Package }}
+{{ end }}
+enum class Split(val splitName: String) {
+{{- range .Splits }}
+    {{ screamingSnake .Words }}({{ quote .Name }}),
+{{- end }}
+}
+{{ range .Experiments }}
+enum class {{ pascal .Words }}Variant(val variantName: String) {
+{{- range .Variants }}
+    {{ screamingSnake .Words }}({{ quote .Name }}),
+{{- end }}
+}
+{{ end }}
+class FeatureGates(private val variantFor: (Split) -> String) {
+{{- range .FeatureGates }}
+    val {{ camel .Words }}: Boolean
+        get() = variantFor(Split.{{ screamingSnake .Words }}) == "true"
+{{- end }}
+}
+`,
+
+	"ruby": `# ` + header + `
+
+module {{ .Package }}
+{{- range .Splits }}
+  {{ screamingSnake .Words }} = {{ rubyQuote .Name }}
+{{- end }}
+{{ range .Experiments }}
+  module {{ pascal .Words }}Variants
+{{- range .Variants }}
+    {{ screamingSnake .Words }} = {{ rubyQuote .Name }}
+{{- end }}
+    ALL = [{{ range $i, $v := .Variants }}{{ if $i }}, {{ end }}{{ screamingSnake $v.Words }}{{ end }}].freeze
+  end
+{{ end }}
+  class FeatureGates
+    def initialize(&variant_for)
+      @variant_for = variant_for
+    end
+{{- range .FeatureGates }}
+
+    def {{ snake .Words }}?
+      @variant_for.call({{ screamingSnake .Words }}) == 'true'
+    end
+{{- end }}
+  end
+end
+`,
+
+	"swift": `// ` + header + `
+
+public enum Split: String, CaseIterable {
+{{- range .Splits }}
+    case {{ swiftCase .Words }} = {{ quote .Name }}
+{{- end }}
+}
+{{ range .Experiments }}
+public enum {{ pascal .Words }}Variant: String, CaseIterable {
+{{- range .Variants }}
+    case {{ swiftCase .Words }} = {{ quote .Name }}
+{{- end }}
+}
+{{ end }}
+public struct FeatureGates {
+    private let variantFor: (Split) -> String
+
+    public init(variantFor: @escaping (Split) -> String) {
+        self.variantFor = variantFor
+    }
+{{ range .FeatureGates }}
+    public var {{ swiftCase .Words }}: Bool {
+        variantFor(.{{ swiftCase .Words }}) == "true"
+    }
+{{ end -}}
+}
+`,
+
+	"ts": `// ` + header + `
+
+export const Splits = {
+{{- range .Splits }}
+  {{ pascal .Words }}: {{ quote .Name }},
+{{- end }}
+} as const;
+
+export type SplitName = (typeof Splits)[keyof typeof Splits];
+{{ range .Experiments }}
+export const {{ pascal .Words }}Variants = {
+{{- range .Variants }}
+  {{ pascal .Words }}: {{ quote .Name }},
+{{- end }}
+} as const;
+
+export type {{ pascal .Words }}Variant = (typeof {{ pascal .Words }}Variants)[keyof typeof {{ pascal .Words }}Variants];
+{{ end }}
+export class FeatureGates {
+  constructor(private readonly variantFor: (splitName: SplitName) => string) {}
+{{ range .FeatureGates }}
+  get {{ camel .Words }}(): boolean {
+    return this.variantFor(Splits.{{ pascal .Words }}) === "true";
+  }
+{{ end -}}
+}
+`,
+}
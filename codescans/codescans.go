@@ -0,0 +1,211 @@
+package codescans
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Betterment/testtrack-cli/migrations"
+	"github.com/Betterment/testtrack-cli/serializers"
+)
+
+// splitTokenRegex matches anything shaped like a split name, prefixed or not
+var splitTokenRegex = regexp.MustCompile(`(?:[a-z_\d\-]+\.)?[a-z_\d]+`)
+
+// quotedSplitRegex matches string literals that look like feature gate or
+// experiment names. Only these are reported as references to unknown splits,
+// because identifiers ending in _enabled are common in code that has nothing
+// to do with TestTrack.
+var quotedSplitRegex = regexp.MustCompile("[\"'`]((?:[a-z_\\d\\-]+\\.)?[a-z_\\d]+_(?:enabled|experiment))[\"'`]")
+
+// skippedDirs are never scanned, in addition to hidden directories
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+}
+
+const maxFileSize = 1 << 20
+
+// Reference is an occurrence of a split name in a file
+type Reference struct {
+	Split string `json:"split"`
+	File  string `json:"file"`
+	Line  int    `json:"line"`
+	// Retired is true if the split once existed in migration history
+	Retired bool `json:"retired,omitempty"`
+}
+
+// Report summarizes split references found in a project
+type Report struct {
+	// References to splits in the schema
+	References []Reference `json:"references"`
+	// Unreferenced lists schema splits with no references, which are safe to retire
+	Unreferenced []string `json:"unreferenced"`
+	// Missing lists quoted references to splits that aren't in the schema
+	Missing []Reference `json:"missing"`
+}
+
+// Scan walks a project tree for references to the splits in a schema, in both
+// their prefixed and unprefixed forms. The testtrack directory at the root is
+// skipped, as are hidden directories, dependency directories, large files and
+// binary files. Splits prefixed with other apps' names are only reported if
+// they're in the schema.
+func Scan(root string, appName string, schema *serializers.Schema, migrationRepo migrations.Repository) (*Report, error) {
+	scanner := newScanner(appName, schema, migrationRepo)
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != root && (strings.HasPrefix(entry.Name(), ".") || skippedDirs[entry.Name()]) {
+				return filepath.SkipDir
+			}
+			if path == filepath.Join(root, "testtrack") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		return scanner.scanFile(path)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return scanner.report(), nil
+}
+
+type scanner struct {
+	appName    string
+	splits     map[string]bool
+	history    map[string]bool
+	references []Reference
+	missing    []Reference
+}
+
+func newScanner(appName string, schema *serializers.Schema, migrationRepo migrations.Repository) *scanner {
+	s := &scanner{
+		appName: appName,
+		splits:  make(map[string]bool, len(schema.Splits)),
+		history: make(map[string]bool),
+	}
+	for _, split := range schema.Splits {
+		s.splits[split.Name] = true
+	}
+	for _, migration := range migrationRepo {
+		file := migration.File()
+		switch {
+		case file.Split != nil:
+			s.history[file.Split.Name] = true
+		case file.SplitRetirement != nil:
+			s.history[file.SplitRetirement.Split] = true
+		}
+	}
+	return s
+}
+
+func (s *scanner) scanFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.Size() > maxFileSize {
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if bytes.IndexByte(content[:min(len(content), 8000)], 0) != -1 {
+		return nil // Binary file
+	}
+
+	lines := bufio.NewScanner(bytes.NewReader(content))
+	lines.Buffer(make([]byte, 0, 64*1024), maxFileSize)
+	lineNumber := 0
+	for lines.Scan() {
+		lineNumber++
+		line := lines.Text()
+		found := make(map[string]bool)
+		for _, token := range splitTokenRegex.FindAllString(line, -1) {
+			split, ok := s.resolve(token)
+			if ok && !found[split] {
+				found[split] = true
+				s.references = append(s.references, Reference{Split: split, File: path, Line: lineNumber})
+			}
+		}
+		for _, match := range quotedSplitRegex.FindAllStringSubmatch(line, -1) {
+			token := match[1]
+			if _, ok := s.resolve(token); ok || !s.ownedOrUnprefixed(token) {
+				continue
+			}
+			split := s.canonicalName(token)
+			s.missing = append(s.missing, Reference{Split: split, File: path, Line: lineNumber, Retired: s.history[split]})
+		}
+	}
+	return lines.Err()
+}
+
+// resolve returns the schema split a token refers to, if any. Prefixed tokens
+// must match a split exactly, so another app's split of the same name isn't
+// mistaken for ours.
+func (s *scanner) resolve(token string) (string, bool) {
+	if s.splits[token] {
+		return token, true
+	}
+	if strings.Contains(token, ".") {
+		return "", false
+	}
+	if s.splits[s.appName+"."+token] {
+		return s.appName + "." + token, true
+	}
+	return "", false
+}
+
+func (s *scanner) ownedOrUnprefixed(token string) bool {
+	return !strings.Contains(token, ".") || strings.HasPrefix(token, s.appName+".")
+}
+
+// canonicalName prefixes unprefixed tokens with the app name, unless they match
+// a retired legacy split
+func (s *scanner) canonicalName(token string) string {
+	if strings.Contains(token, ".") || s.history[token] {
+		return token
+	}
+	return s.appName + "." + token
+}
+
+func (s *scanner) report() *Report {
+	referenced := make(map[string]bool)
+	for _, reference := range s.references {
+		referenced[reference.Split] = true
+	}
+	unreferenced := []string{}
+	for split := range s.splits {
+		if !referenced[split] {
+			unreferenced = append(unreferenced, split)
+		}
+	}
+	sort.Strings(unreferenced)
+
+	report := &Report{
+		References:   s.references,
+		Unreferenced: unreferenced,
+		Missing:      s.missing,
+	}
+	if report.References == nil {
+		report.References = []Reference{}
+	}
+	if report.Missing == nil {
+		report.Missing = []Reference{}
+	}
+	return report
+}
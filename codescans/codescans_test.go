@@ -0,0 +1,54 @@
+package codescans_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Betterment/testtrack-cli/codescans"
+	"github.com/Betterment/testtrack-cli/migrations"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/splitretirements"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan(t *testing.T) {
+	root := t.TempDir()
+	write := func(path, content string) {
+		path = filepath.Join(root, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	write("app/models/user.rb", "if TestTrack.ab(:fancy_experiment)\n  config[:banner_enabled]\nend\n")
+	write("app/models/partner.rb", "TestTrack.ab(:'other_app.unused_enabled')\n")
+	write("web/src/app.ts", "const flag = 'my_app.typo_enabled';\nuse(\"old_enabled\");\n")
+	write("web/node_modules/lib/index.js", "'my_app.unused_enabled'\n")
+	write(".git/HEAD", "unused_enabled\n")
+	write("testtrack/schema.yml", "name: my_app.unused_enabled\n")
+	write("bin/blob", "my_app.unused_enabled\x00")
+
+	schema := &serializers.Schema{
+		Splits: []serializers.SchemaSplit{
+			{Name: "my_app.fancy_experiment"},
+			{Name: "my_app.banner_enabled"},
+			{Name: "my_app.unused_enabled"},
+		},
+	}
+	split, decision := "my_app.old_enabled", "false"
+	retirement, err := splitretirements.New(&split, &decision)
+	require.NoError(t, err)
+	repo := migrations.Repository{"2020010100000": retirement}
+
+	report, err := codescans.Scan(root, "my_app", schema, repo)
+	require.NoError(t, err)
+
+	require.Equal(t, []codescans.Reference{
+		{Split: "my_app.fancy_experiment", File: filepath.Join(root, "app/models/user.rb"), Line: 1},
+		{Split: "my_app.banner_enabled", File: filepath.Join(root, "app/models/user.rb"), Line: 2},
+	}, report.References)
+	require.Equal(t, []string{"my_app.unused_enabled"}, report.Unreferenced)
+	require.Equal(t, []codescans.Reference{
+		{Split: "my_app.typo_enabled", File: filepath.Join(root, "web/src/app.ts"), Line: 1},
+		{Split: "my_app.old_enabled", File: filepath.Join(root, "web/src/app.ts"), Line: 2, Retired: true},
+	}, report.Missing)
+}
@@ -0,0 +1,89 @@
+package expirations
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/featurecompletions"
+	"github.com/Betterment/testtrack-cli/migrations"
+	"github.com/Betterment/testtrack-cli/remotekills"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/validations"
+)
+
+// Kinds of resources that can expire
+const (
+	RemoteKill        = "remote_kill"
+	FeatureCompletion = "feature_completion"
+)
+
+// Expiration describes a remote kill or feature completion that is past its
+// expires_at date
+type Expiration struct {
+	Kind      string `json:"kind"`
+	Resource  string `json:"resource"`
+	Split     string `json:"split"`
+	Reason    string `json:"reason,omitempty"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// Find returns the remote kills and feature completions in a schema that
+// expired on or before now. A resource expires at the start of its
+// expires_at date, UTC.
+func Find(schema *serializers.Schema, now time.Time) ([]Expiration, error) {
+	expirations := []Expiration{}
+	for _, remoteKill := range schema.RemoteKills {
+		expired, err := isExpired(remoteKill.ExpiresAt, now)
+		if err != nil {
+			return nil, fmt.Errorf("remote_kill %s of %s: %w", remoteKill.Reason, remoteKill.Split, err)
+		}
+		if expired {
+			expirations = append(expirations, Expiration{
+				Kind:      RemoteKill,
+				Resource:  remoteKill.Split + ":" + remoteKill.Reason,
+				Split:     remoteKill.Split,
+				Reason:    remoteKill.Reason,
+				ExpiresAt: *remoteKill.ExpiresAt,
+			})
+		}
+	}
+	for _, featureCompletion := range schema.FeatureCompletions {
+		expired, err := isExpired(featureCompletion.ExpiresAt, now)
+		if err != nil {
+			return nil, fmt.Errorf("feature_completion of %s: %w", featureCompletion.FeatureGate, err)
+		}
+		if expired {
+			expirations = append(expirations, Expiration{
+				Kind:      FeatureCompletion,
+				Resource:  featureCompletion.FeatureGate,
+				Split:     featureCompletion.FeatureGate,
+				ExpiresAt: *featureCompletion.ExpiresAt,
+			})
+		}
+	}
+	return expirations, nil
+}
+
+// DestroyMigration returns a migration destroying the expired resource
+func (e *Expiration) DestroyMigration() (migrations.IMigration, error) {
+	split := e.Split
+	switch e.Kind {
+	case RemoteKill:
+		reason := e.Reason
+		return remotekills.New(&split, &reason, nil, nil, nil, nil)
+	case FeatureCompletion:
+		return featurecompletions.New(&split, nil, nil)
+	}
+	return nil, fmt.Errorf("unknown expiration kind %s", e.Kind)
+}
+
+func isExpired(expiresAt *string, now time.Time) (bool, error) {
+	if expiresAt == nil || *expiresAt == "" {
+		return false, nil
+	}
+	t, err := time.Parse(validations.DateLayout, *expiresAt)
+	if err != nil {
+		return false, fmt.Errorf("expires_at '%s' must be a date formatted YYYY-MM-DD", *expiresAt)
+	}
+	return !now.Before(t), nil
+}
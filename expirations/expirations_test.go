@@ -0,0 +1,50 @@
+package expirations_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/expirations"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFind(t *testing.T) {
+	past, today, future, version := "2020-06-01", "2020-06-30", "2020-07-01", "1.0"
+	schema := &serializers.Schema{
+		RemoteKills: []serializers.RemoteKill{
+			{Split: "my_app.foo_experiment", Reason: "expired_bug", FirstBadVersion: &version, ExpiresAt: &past},
+			{Split: "my_app.foo_experiment", Reason: "future_bug", FirstBadVersion: &version, ExpiresAt: &future},
+			{Split: "my_app.foo_experiment", Reason: "forever_bug", FirstBadVersion: &version},
+		},
+		FeatureCompletions: []serializers.FeatureCompletion{
+			{FeatureGate: "my_app.bar_enabled", Version: &version, ExpiresAt: &today},
+		},
+	}
+	now := time.Date(2020, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	found, err := expirations.Find(schema, now)
+	require.NoError(t, err)
+	require.Equal(t, []expirations.Expiration{
+		{Kind: expirations.RemoteKill, Resource: "my_app.foo_experiment:expired_bug", Split: "my_app.foo_experiment", Reason: "expired_bug", ExpiresAt: past},
+		{Kind: expirations.FeatureCompletion, Resource: "my_app.bar_enabled", Split: "my_app.bar_enabled", ExpiresAt: today},
+	}, found)
+
+	t.Run("it generates destroy migrations", func(t *testing.T) {
+		migration, err := found[0].DestroyMigration()
+		require.NoError(t, err)
+		require.Nil(t, migration.File().RemoteKill.FirstBadVersion)
+		require.Equal(t, "expired_bug", migration.File().RemoteKill.Reason)
+
+		migration, err = found[1].DestroyMigration()
+		require.NoError(t, err)
+		require.Nil(t, migration.File().FeatureCompletion.Version)
+	})
+
+	t.Run("it rejects malformed dates", func(t *testing.T) {
+		malformed := "next week"
+		schema.FeatureCompletions[0].ExpiresAt = &malformed
+		_, err := expirations.Find(schema, now)
+		require.Error(t, err)
+	})
+}
@@ -1,51 +1,141 @@
 package fakeassignments
 
 import (
+	"crypto/md5"
+	"encoding/binary"
 	"os"
 
 	"github.com/Betterment/testtrack-cli/paths"
+	"github.com/Betterment/testtrack-cli/splits"
 	"gopkg.in/yaml.v2"
 )
 
+// Visitors is the YAML-marshalable per-visitor state of the fake server
+type Visitors struct {
+	// Assignments maps visitor IDs to split names to variants
+	Assignments map[string]map[string]string `yaml:"assignments"`
+	// Identifiers maps identifier types to identifier values to visitor IDs
+	Identifiers map[string]map[string]string `yaml:"identifiers"`
+}
+
 // Read reads or creates the assignment file
 func Read() (*map[string]string, error) {
+	assignmentsBytes, err := readOrCreate("assignments.yml")
+	if err != nil {
+		return nil, err
+	}
+	var assignments map[string]string
+	err = yaml.Unmarshal(assignmentsBytes, &assignments)
+	if err != nil {
+		return nil, err
+	}
+	if assignments == nil {
+		assignments = make(map[string]string)
+	}
+	return &assignments, nil
+}
+
+// Write dumps the assignment file to disk
+func Write(assignments *map[string]string) error {
+	return write("assignments.yml", assignments)
+}
+
+// ReadVisitors reads or creates the per-visitor state file
+func ReadVisitors() (*Visitors, error) {
+	visitorsBytes, err := readOrCreate("visitors.yml")
+	if err != nil {
+		return nil, err
+	}
+	var visitors Visitors
+	err = yaml.Unmarshal(visitorsBytes, &visitors)
+	if err != nil {
+		return nil, err
+	}
+	if visitors.Assignments == nil {
+		visitors.Assignments = make(map[string]map[string]string)
+	}
+	if visitors.Identifiers == nil {
+		visitors.Identifiers = make(map[string]map[string]string)
+	}
+	return &visitors, nil
+}
+
+// WriteVisitors dumps the per-visitor state file to disk
+func WriteVisitors(visitors *Visitors) error {
+	return write("visitors.yml", visitors)
+}
+
+// AssignmentsFor returns the assignments for a visitor, layering the
+// visitor's own assignments over the global ones
+func AssignmentsFor(globalAssignments map[string]string, visitors *Visitors, visitorID string) map[string]string {
+	assignments := make(map[string]string, len(globalAssignments))
+	for split, variant := range globalAssignments {
+		assignments[split] = variant
+	}
+	for split, variant := range visitors.Assignments[visitorID] {
+		assignments[split] = variant
+	}
+	return assignments
+}
+
+// Assign records a variant for a visitor
+func (v *Visitors) Assign(visitorID, split, variant string) {
+	if v.Assignments[visitorID] == nil {
+		v.Assignments[visitorID] = make(map[string]string)
+	}
+	v.Assignments[visitorID][split] = variant
+}
+
+// VisitorIDFor returns the visitor ID associated with an identifier, if any
+func (v *Visitors) VisitorIDFor(identifierType, identifierValue string) (string, bool) {
+	visitorID, ok := v.Identifiers[identifierType][identifierValue]
+	return visitorID, ok
+}
+
+// Identify associates an identifier with a visitor
+func (v *Visitors) Identify(identifierType, identifierValue, visitorID string) {
+	if v.Identifiers[identifierType] == nil {
+		v.Identifiers[identifierType] = make(map[string]string)
+	}
+	v.Identifiers[identifierType][identifierValue] = visitorID
+}
+
+// WeightedVariant deterministically picks a variant of a split for a visitor,
+// bucketing the visitor the same way TestTrack does
+func WeightedVariant(visitorID, splitName string, weights splits.Weights) string {
+	digest := md5.Sum([]byte(splitName + visitorID))
+	bucket := int(binary.BigEndian.Uint32(digest[:4]) % 100)
+	return weights.VariantForBucket(bucket)
+}
+
+func readOrCreate(filename string) ([]byte, error) {
 	configDir, err := paths.FakeServerConfigDir()
 	if err != nil {
 		return nil, err
 	}
-	if _, err := os.Stat(*configDir + "/assignments.yml"); os.IsNotExist(err) {
+	if _, err := os.Stat(*configDir + "/" + filename); os.IsNotExist(err) {
 		err := os.MkdirAll(*configDir, 0755)
 		if err != nil {
 			return nil, err
 		}
-		err = os.WriteFile(*configDir+"/assignments.yml", []byte("{}"), 0644)
+		err = os.WriteFile(*configDir+"/"+filename, []byte("{}"), 0644)
 		if err != nil {
 			return nil, err
 		}
 	}
-	assignmentsBytes, err := os.ReadFile(*configDir + "/assignments.yml")
-	if err != nil {
-		return nil, err
-	}
-	var assignments map[string]string
-	err = yaml.Unmarshal(assignmentsBytes, &assignments)
-	if err != nil {
-		return nil, err
-	}
-	return &assignments, nil
+	return os.ReadFile(*configDir + "/" + filename)
 }
 
-// Write dumps the assignment file to disk
-func Write(assignments *map[string]string) error {
+func write(filename string, v interface{}) error {
 	configDir, err := paths.FakeServerConfigDir()
 	if err != nil {
 		return err
 	}
-	bytes, err := yaml.Marshal(assignments)
+	bytes, err := yaml.Marshal(v)
 	if err != nil {
 		return err
 	}
-	err = os.WriteFile(*configDir+"/assignments.yml", bytes, 0644)
+	err = os.WriteFile(*configDir+"/"+filename, bytes, 0644)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,190 @@
+package fakeevents
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/paths"
+)
+
+// Kinds of events the fake server records
+const (
+	KindAssignmentEvent    = "assignment_event"
+	KindIdentifier         = "identifier"
+	KindAssignmentOverride = "assignment_override"
+)
+
+const filename = "events.jsonl"
+
+// maxLogBytes caps the event log. Once an append would take it past the cap,
+// the log is rotated to events.jsonl.1, replacing the previous rotation, so
+// at most twice this much is kept and the oldest events are dropped first.
+var maxLogBytes int64 = 10 * 1024 * 1024
+
+// Event is the JSON-marshalable record of a request a client made to the fake
+// server
+type Event struct {
+	Time            time.Time `json:"time"`
+	Kind            string    `json:"kind"`
+	VisitorID       string    `json:"visitor_id,omitempty"`
+	SplitName       string    `json:"split_name,omitempty"`
+	Variant         string    `json:"variant,omitempty"`
+	Context         string    `json:"context,omitempty"`
+	MixpanelResult  string    `json:"mixpanel_result,omitempty"`
+	IdentifierType  string    `json:"identifier_type,omitempty"`
+	IdentifierValue string    `json:"identifier_value,omitempty"`
+}
+
+// Filter selects events. Zero-valued fields match every event.
+type Filter struct {
+	SplitName string
+	VisitorID string
+	Since     time.Time
+}
+
+// Matches returns true if the event is selected by the filter
+func (f Filter) Matches(event Event) bool {
+	if f.SplitName != "" && event.SplitName != f.SplitName {
+		return false
+	}
+	if f.VisitorID != "" && event.VisitorID != f.VisitorID {
+		return false
+	}
+	if !f.Since.IsZero() && event.Time.Before(f.Since) {
+		return false
+	}
+	return true
+}
+
+// ParseSince parses a --since value, either an RFC3339 timestamp or a
+// duration before now like 15m
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("since %s must be an RFC3339 timestamp like 2006-01-02T15:04:05Z or a duration like 15m", value)
+	}
+	return now.Add(-d), nil
+}
+
+// Append records an event in the event log, stamping it with the current
+// time if it doesn't have one
+func Append(event Event) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	path, err := logPath()
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err == nil && info.Size()+int64(len(bytes))+1 > maxLogBytes {
+		err = os.Rename(path, path+".1")
+		if err != nil {
+			return err
+		}
+	} else if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(bytes, '\n'))
+	if err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Read returns the events in the event log selected by the filter, oldest
+// first
+func Read(filter Filter) ([]Event, error) {
+	path, err := logPath()
+	if err != nil {
+		return nil, err
+	}
+	events := []Event{}
+	for _, logFile := range []string{path + ".1", path} {
+		events, err = readFile(logFile, filter, events)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// readFile appends the events in a log file selected by the filter
+func readFile(path string, filter Filter, events []Event) ([]Event, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return events, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var event Event
+		err := json.Unmarshal(scanner.Bytes(), &event)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %s line %d: %w", path, line, err)
+		}
+		if filter.Matches(event) {
+			events = append(events, event)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// Clear empties the event log
+func Clear() error {
+	path, err := logPath()
+	if err != nil {
+		return err
+	}
+	for _, logFile := range []string{path + ".1", path} {
+		err = os.Remove(logFile)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func logPath() (string, error) {
+	configDir, err := paths.FakeServerConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(*configDir, filename), nil
+}
@@ -0,0 +1,107 @@
+package fakeevents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvents(t *testing.T) {
+	t.Setenv("TESTTRACK_FAKE_SERVER_CONFIG_DIR", t.TempDir())
+	start := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("it reads an empty log before anything is recorded", func(t *testing.T) {
+		events, err := Read(Filter{})
+		require.Nil(t, err)
+		require.Empty(t, events)
+	})
+
+	require.Nil(t, Append(Event{Time: start, Kind: KindIdentifier, VisitorID: "a", IdentifierType: "user_id", IdentifierValue: "42"}))
+	require.Nil(t, Append(Event{Time: start.Add(time.Minute), Kind: KindAssignmentEvent, VisitorID: "a", SplitName: "test.foo_experiment", Variant: "control"}))
+	require.Nil(t, Append(Event{Time: start.Add(2 * time.Minute), Kind: KindAssignmentOverride, VisitorID: "b", SplitName: "test.foo_experiment", Variant: "treatment"}))
+
+	t.Run("it reads events oldest first", func(t *testing.T) {
+		events, err := Read(Filter{})
+		require.Nil(t, err)
+		require.Len(t, events, 3)
+		require.Equal(t, KindIdentifier, events[0].Kind)
+		require.Equal(t, "42", events[0].IdentifierValue)
+		require.Equal(t, KindAssignmentOverride, events[2].Kind)
+	})
+
+	t.Run("it filters by split, visitor and time", func(t *testing.T) {
+		events, err := Read(Filter{SplitName: "test.foo_experiment"})
+		require.Nil(t, err)
+		require.Len(t, events, 2)
+
+		events, err = Read(Filter{SplitName: "test.foo_experiment", VisitorID: "a"})
+		require.Nil(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, KindAssignmentEvent, events[0].Kind)
+
+		events, err = Read(Filter{Since: start.Add(time.Minute)})
+		require.Nil(t, err)
+		require.Len(t, events, 2)
+	})
+
+	t.Run("it clears the log", func(t *testing.T) {
+		require.Nil(t, Clear())
+		require.Nil(t, Clear())
+
+		events, err := Read(Filter{})
+		require.Nil(t, err)
+		require.Empty(t, events)
+	})
+}
+
+func TestEventsRotation(t *testing.T) {
+	t.Setenv("TESTTRACK_FAKE_SERVER_CONFIG_DIR", t.TempDir())
+	defer func(previous int64) { maxLogBytes = previous }(maxLogBytes)
+	maxLogBytes = 300
+	start := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for i := 0; i < 10; i++ {
+		require.Nil(t, Append(Event{Time: start.Add(time.Duration(i) * time.Minute), Kind: KindAssignmentEvent, VisitorID: "a", SplitName: "test.foo_experiment", Variant: "control"}))
+	}
+
+	t.Run("it keeps the newest events within the cap", func(t *testing.T) {
+		events, err := Read(Filter{})
+		require.Nil(t, err)
+		require.Less(t, len(events), 10)
+		require.Equal(t, start.Add(9*time.Minute), events[len(events)-1].Time)
+		for i := 1; i < len(events); i++ {
+			require.True(t, events[i-1].Time.Before(events[i].Time))
+		}
+	})
+
+	t.Run("it clears rotated events", func(t *testing.T) {
+		require.Nil(t, Clear())
+
+		events, err := Read(Filter{})
+		require.Nil(t, err)
+		require.Empty(t, events)
+	})
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	since, err := ParseSince("", now)
+	require.Nil(t, err)
+	require.True(t, since.IsZero())
+
+	since, err = ParseSince("15m", now)
+	require.Nil(t, err)
+	require.Equal(t, now.Add(-15*time.Minute), since)
+
+	since, err = ParseSince("2020-01-01T00:00:00Z", now)
+	require.Nil(t, err)
+	require.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), since)
+
+	_, err = ParseSince("yesterday", now)
+	require.Error(t, err)
+
+	_, err = ParseSince("-5m", now)
+	require.Error(t, err)
+}
@@ -0,0 +1,185 @@
+package fakeserver
+
+import (
+	_ "embed" // for the admin page
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/fakeassignments"
+	"github.com/Betterment/testtrack-cli/fakeevents"
+	"github.com/Betterment/testtrack-cli/validations"
+)
+
+//go:embed admin.html
+var adminPage []byte
+
+type adminAssignments struct {
+	// Assignments are the overrides that apply to every visitor
+	Assignments map[string]string `json:"assignments"`
+	// Visitors maps visitor IDs to the overrides made for just that visitor
+	Visitors map[string]map[string]string `json:"visitors"`
+}
+
+type adminAssignmentRequestBody struct {
+	SplitName string `json:"split_name"`
+	Variant   string `json:"variant"`
+	VisitorID string `json:"visitor_id"`
+}
+
+func (s *server) adminRoutes() {
+	s.router.HandleFunc("/admin", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(adminPage)
+	}).Methods("GET")
+	s.handleGet(
+		"/admin/schema",
+		getAdminSchema,
+	)
+	s.handleGet(
+		"/admin/assignments",
+		getAdminAssignments,
+	)
+	s.handlePost(
+		"/admin/assignments",
+		postAdminAssignment,
+	)
+	s.handleGet(
+		"/admin/events",
+		getAdminEvents,
+	)
+	s.handlePostReturnNoContent(
+		"/admin/reset",
+		postAdminReset,
+	)
+}
+
+func getAdminSchema(*http.Request) (interface{}, error) {
+	return readMerged()
+}
+
+func getAdminAssignments(*http.Request) (interface{}, error) {
+	return readAdminAssignments()
+}
+
+func readAdminAssignments() (*adminAssignments, error) {
+	assignments, err := readAssignments()
+	if err != nil {
+		return nil, err
+	}
+	visitors, err := readVisitors()
+	if err != nil {
+		return nil, err
+	}
+	return &adminAssignments{
+		Assignments: *assignments,
+		Visitors:    visitors.Assignments,
+	}, nil
+}
+
+// getAdminEvents lists recorded events, filtered by the split, visitor and
+// since query params
+func getAdminEvents(r *http.Request) (interface{}, error) {
+	query := r.URL.Query()
+	since, err := fakeevents.ParseSince(query.Get("since"), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", err.Error(), errBadRequest)
+	}
+	events, err := fakeevents.Read(fakeevents.Filter{
+		SplitName: query.Get("split"),
+		VisitorID: query.Get("visitor"),
+		Since:     since,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]fakeevents.Event{"events": events}, nil
+}
+
+// postAdminAssignment sets an override for every visitor, or for one visitor
+// if visitor_id is given. An empty variant removes the override.
+func postAdminAssignment(r *http.Request) (interface{}, error) {
+	// Requiring JSON keeps other sites from posting plain forms to the admin
+	// routes of a developer's running server
+	contentType := r.Header.Get("content-type")
+	if !strings.HasPrefix(contentType, "application/json") {
+		return nil, fmt.Errorf("got unexpected content type %s: %w", contentType, errBadRequest)
+	}
+	requestBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	var body adminAssignmentRequestBody
+	err = json.Unmarshal(requestBytes, &body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", err.Error(), errBadRequest)
+	}
+
+	if body.Variant != "" {
+		mergedSchema, err := readMerged()
+		if err != nil {
+			return nil, err
+		}
+		err = validations.VariantExistsInSchema("variant", &body.Variant, body.SplitName, mergedSchema)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", err.Error(), errBadRequest)
+		}
+	}
+
+	if body.VisitorID == "" {
+		assignments, err := readAssignments()
+		if err != nil {
+			return nil, err
+		}
+		if body.Variant == "" {
+			delete(*assignments, body.SplitName)
+		} else {
+			(*assignments)[body.SplitName] = body.Variant
+		}
+		err = writeAssignments(assignments)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		visitors, err := readVisitors()
+		if err != nil {
+			return nil, err
+		}
+		if body.Variant == "" {
+			delete(visitors.Assignments[body.VisitorID], body.SplitName)
+			if len(visitors.Assignments[body.VisitorID]) == 0 {
+				delete(visitors.Assignments, body.VisitorID)
+			}
+		} else {
+			visitors.Assign(body.VisitorID, body.SplitName, body.Variant)
+		}
+		err = writeVisitors(visitors)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return readAdminAssignments()
+}
+
+// postAdminReset clears every override, identified visitor and recorded event
+func postAdminReset(r *http.Request) error {
+	contentType := r.Header.Get("content-type")
+	if !strings.HasPrefix(contentType, "application/json") {
+		return fmt.Errorf("got unexpected content type %s: %w", contentType, errBadRequest)
+	}
+	err := writeAssignments(&map[string]string{})
+	if err != nil {
+		return err
+	}
+	err = fakeevents.Clear()
+	if err != nil {
+		return err
+	}
+	return writeVisitors(&fakeassignments.Visitors{
+		Assignments: map[string]map[string]string{},
+		Identifiers: map[string]map[string]string{},
+	})
+}
@@ -0,0 +1,123 @@
+package fakeserver
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/gorilla/mux"
+)
+
+// appBuildSchema returns the merged schema with splits reweighted to the
+// variants the app and version in the request path are forced to, and those
+// forced variants by split name
+func appBuildSchema(r *http.Request) (*serializers.Schema, map[string]string, error) {
+	schema, err := readMerged()
+	if err != nil {
+		return nil, nil, err
+	}
+	vars := mux.Vars(r)
+	overrides := appVersionOverrides(schema, vars["a"], vars["v"])
+	for i, split := range schema.Splits {
+		variant, ok := overrides[split.Name]
+		if !ok {
+			continue
+		}
+		weights := make(map[string]int, len(split.Weights))
+		for name := range split.Weights {
+			weights[name] = 0
+		}
+		weights[variant] = 100
+		schema.Splits[i].Weights = weights
+	}
+	return schema, overrides, nil
+}
+
+// appVersionOverrides returns the variant each of an app's splits is forced
+// to for a version of the app, the way the TestTrack server does for app
+// builds:
+//
+// * remote kills force their override_to variant from first_bad_version up to
+// but not including fixed_version
+// * feature gates with a feature completion are forced to false for versions
+// before it, which takes precedence over remote kills
+//
+// Linked schemas don't record which app they belong to, so a split belongs to
+// the app its name is prefixed with. Versions that can't be parsed aren't
+// overridden, so those builds see the schema as it is.
+func appVersionOverrides(schema *serializers.Schema, appName, appVersion string) map[string]string {
+	overrides := map[string]string{}
+	version, ok := parseAppVersion(appVersion)
+	if !ok {
+		return overrides
+	}
+	prefix := appName + "."
+
+	for _, remoteKill := range schema.RemoteKills {
+		if !strings.HasPrefix(remoteKill.Split, prefix) || remoteKill.FirstBadVersion == nil || remoteKill.OverrideTo == nil {
+			continue
+		}
+		firstBadVersion, ok := parseAppVersion(*remoteKill.FirstBadVersion)
+		if !ok || compareAppVersions(version, firstBadVersion) < 0 {
+			continue
+		}
+		if remoteKill.FixedVersion != nil && *remoteKill.FixedVersion != "" {
+			fixedVersion, ok := parseAppVersion(*remoteKill.FixedVersion)
+			if ok && compareAppVersions(version, fixedVersion) >= 0 {
+				continue
+			}
+		}
+		overrides[remoteKill.Split] = *remoteKill.OverrideTo
+	}
+
+	for _, featureCompletion := range schema.FeatureCompletions {
+		if !strings.HasPrefix(featureCompletion.FeatureGate, prefix) || featureCompletion.Version == nil {
+			continue
+		}
+		completedVersion, ok := parseAppVersion(*featureCompletion.Version)
+		if ok && compareAppVersions(version, completedVersion) < 0 {
+			overrides[featureCompletion.FeatureGate] = "false"
+		}
+	}
+	return overrides
+}
+
+// compareAppVersions compares parsed app versions numerically, treating
+// missing components as zero
+func compareAppVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var aPart, bPart int
+		if i < len(a) {
+			aPart = a[i]
+		}
+		if i < len(b) {
+			bPart = b[i]
+		}
+		if aPart != bPart {
+			if aPart < bPart {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// parseAppVersion parses a dotted app version into its integer components,
+// ignoring any pre-release or build suffix like 1.2.3-beta
+func parseAppVersion(version string) ([]int, bool) {
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	components := strings.Split(version, ".")
+	parts := make([]int, 0, len(components))
+	for _, component := range components {
+		part, err := strconv.Atoi(component)
+		if err != nil || part < 0 {
+			return nil, false
+		}
+		parts = append(parts, part)
+	}
+	return parts, true
+}
@@ -1,15 +1,19 @@
 package fakeserver
 
 import (
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/Betterment/testtrack-cli/fakeassignments"
-	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/Betterment/testtrack-cli/fakeevents"
+	"github.com/Betterment/testtrack-cli/serializers"
 	"github.com/Betterment/testtrack-cli/splits"
+	"github.com/gorilla/mux"
 )
 
 // v1Visitor is the JSON output type for V1 visitor endpoints
@@ -122,6 +126,24 @@ type v1AssignmentDetail struct {
 	AssignedAt         string `json:"assigned_at"`
 }
 
+// v1AssignmentEventRequestBody is the JSON input for the V1 assignment_event endpoint
+type v1AssignmentEventRequestBody struct {
+	VisitorID      string `json:"visitor_id"`
+	SplitName      string `json:"split_name"`
+	Context        string `json:"context"`
+	MixpanelResult string `json:"mixpanel_result"`
+}
+
+// v1IdentifierRequestBody is the JSON input for the V1 and V4 identifier endpoints
+type v1IdentifierRequestBody struct {
+	IdentifierType string `json:"identifier_type"`
+	Value          string `json:"value"`
+	VisitorID      string `json:"visitor_id"`
+}
+
+// defaultVisitorID is the visitor we fall back to when a client doesn't identify one
+const defaultVisitorID = "00000000-0000-0000-0000-000000000000"
+
 func (s *server) routes() {
 	s.handleGet(
 		"/api/v1/split_registry",
@@ -133,7 +155,7 @@ func (s *server) routes() {
 	)
 	s.handlePostReturnNoContent(
 		"/api/v1/assignment_event",
-		postNoop,
+		postV1AssignmentEvent,
 	)
 	s.handlePost(
 		"/api/v1/identifier",
@@ -149,7 +171,7 @@ func (s *server) routes() {
 	)
 	s.handleGet(
 		"/api/v1/identifier_types/{t}/identifiers/{i}/visitor",
-		getV1Visitor,
+		getV1IdentifierVisitor,
 	)
 	s.handleGet(
 		"/api/v1/identifier_types/{t}/identifiers/{i}/visitor_detail",
@@ -183,28 +205,38 @@ func (s *server) routes() {
 		"/api/v4/builds/{b}/split_registry",
 		getV4SplitRegistry,
 	)
+	s.adminRoutes()
 }
 
-func getV1SplitRegistry() (interface{}, error) {
-	schema, err := schema.ReadMerged()
+func getV1SplitRegistry(*http.Request) (interface{}, error) {
+	schema, err := readMerged()
 	if err != nil {
 		return nil, err
 	}
+	return buildV1SplitRegistry(schema)
+}
+
+func buildV1SplitRegistry(schema *serializers.Schema) (map[string]*splits.Weights, error) {
 	splitRegistry := map[string]*splits.Weights{}
 	for _, split := range schema.Splits {
-		splitRegistry[split.Name], err = splits.NewWeights(split.Weights)
+		weights, err := splits.NewWeights(split.Weights)
 		if err != nil {
 			return nil, err
 		}
+		splitRegistry[split.Name] = weights
 	}
 	return splitRegistry, nil
 }
 
-func getV2PlusSplitRegistry() (interface{}, error) {
-	schema, err := schema.ReadMerged()
+func getV2PlusSplitRegistry(*http.Request) (interface{}, error) {
+	schema, err := readMerged()
 	if err != nil {
 		return nil, err
 	}
+	return buildV2PlusSplitRegistry(schema)
+}
+
+func buildV2PlusSplitRegistry(schema *serializers.Schema) (*v2SplitRegistry, error) {
 	splitRegistry := map[string]*v2Split{}
 	for _, split := range schema.Splits {
 		isFeatureGate := splits.IsFeatureGateFromName(split.Name)
@@ -217,17 +249,21 @@ func getV2PlusSplitRegistry() (interface{}, error) {
 			FeatureGate: isFeatureGate,
 		}
 	}
-	return v2SplitRegistry{
+	return &v2SplitRegistry{
 		Splits:                   splitRegistry,
 		ExperienceSamplingWeight: 1,
 	}, nil
 }
 
-func getV4SplitRegistry() (interface{}, error) {
-	schema, err := schema.ReadMerged()
+func getV4SplitRegistry(*http.Request) (interface{}, error) {
+	schema, err := readMerged()
 	if err != nil {
 		return nil, err
 	}
+	return buildV4SplitRegistry(schema)
+}
+
+func buildV4SplitRegistry(schema *serializers.Schema) (*v4SplitRegistry, error) {
 	v4Splits := make([]v4Split, 0, len(schema.Splits))
 	for _, split := range schema.Splits {
 		isFeatureGate := splits.IsFeatureGateFromName(split.Name)
@@ -242,93 +278,334 @@ func getV4SplitRegistry() (interface{}, error) {
 				Weight: weight,
 			})
 		}
+		// Keep responses stable so recordings replay cleanly
+		sort.Slice(v4Variants, func(i, j int) bool {
+			return v4Variants[i].Name < v4Variants[j].Name
+		})
 		v4Splits = append(v4Splits, v4Split{
 			Name:        split.Name,
 			Variants:    v4Variants,
 			FeatureGate: isFeatureGate,
 		})
 	}
-	return v4SplitRegistry{
+	return &v4SplitRegistry{
 		Splits:                   v4Splits,
 		ExperienceSamplingWeight: 1,
 	}, nil
 }
 
-func postNoop(*http.Request) error {
-	return nil
+func postV1AssignmentEvent(r *http.Request) error {
+	event := fakeevents.Event{Kind: fakeevents.KindAssignmentEvent}
+	contentType := r.Header.Get("content-type")
+	switch {
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		err := r.ParseForm()
+		if err != nil {
+			return err
+		}
+		event.VisitorID = r.PostForm.Get("visitor_id")
+		event.SplitName = r.PostForm.Get("split_name")
+		event.Context = r.PostForm.Get("context")
+		event.MixpanelResult = r.PostForm.Get("mixpanel_result")
+	case strings.HasPrefix(contentType, "application/json"):
+		requestBytes, err := io.ReadAll(r.Body)
+		if err != nil {
+			return err
+		}
+		var body v1AssignmentEventRequestBody
+		err = json.Unmarshal(requestBytes, &body)
+		if err != nil {
+			return err
+		}
+		event.VisitorID = body.VisitorID
+		event.SplitName = body.SplitName
+		event.Context = body.Context
+		event.MixpanelResult = body.MixpanelResult
+	default:
+		return fmt.Errorf("got unexpected content type %s", contentType)
+	}
+
+	// Clients don't say which variant they saw, so record the one we'd serve
+	assignments, err := readAssignments()
+	if err != nil {
+		return err
+	}
+	visitors, err := readVisitors()
+	if err != nil {
+		return err
+	}
+	event.Variant = fakeassignments.AssignmentsFor(*assignments, visitors, event.VisitorID)[event.SplitName]
+	return fakeevents.Append(event)
+}
+
+func postV1Identifier(r *http.Request) (interface{}, error) {
+	visitorID, err := identify(r)
+	if err != nil {
+		return nil, err
+	}
+	visitor, err := v1VisitorFor(visitorID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]*v1Visitor{"visitor": visitor}, nil
 }
 
-func postV1Identifier(*http.Request) (interface{}, error) {
-	ivisitor, err := getV1Visitor()
-	visitor := ivisitor.(v1Visitor)
+func postV4AppIdentifier(r *http.Request) (interface{}, error) {
+	schema, overrides, err := appBuildSchema(r)
 	if err != nil {
 		return nil, err
 	}
-	return map[string]v1Visitor{"visitor": visitor}, nil
+	visitorID, err := identify(r)
+	if err != nil {
+		return nil, err
+	}
+	return v4AppVisitorConfigFor(visitorID, schema, overrides)
+}
+
+// identify associates the identifier in the request body with a visitor,
+// returning the visitor that already owns the identifier if there is one
+func identify(r *http.Request) (string, error) {
+	var body v1IdentifierRequestBody
+	contentType := r.Header.Get("content-type")
+	switch {
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		err := r.ParseForm()
+		if err != nil {
+			return "", err
+		}
+		body = v1IdentifierRequestBody{
+			IdentifierType: r.PostForm.Get("identifier_type"),
+			Value:          r.PostForm.Get("value"),
+			VisitorID:      r.PostForm.Get("visitor_id"),
+		}
+	case strings.HasPrefix(contentType, "application/json"):
+		requestBytes, err := io.ReadAll(r.Body)
+		if err != nil {
+			return "", err
+		}
+		if len(requestBytes) != 0 {
+			err = json.Unmarshal(requestBytes, &body)
+			if err != nil {
+				return "", err
+			}
+		}
+	default:
+		return "", fmt.Errorf("got unexpected content type %s", contentType)
+	}
+
+	if body.VisitorID == "" {
+		body.VisitorID = defaultVisitorID
+	}
+	if body.IdentifierType == "" || body.Value == "" {
+		return body.VisitorID, nil
+	}
+
+	visitors, err := readVisitors()
+	if err != nil {
+		return "", err
+	}
+	visitorID, ok := visitors.VisitorIDFor(body.IdentifierType, body.Value)
+	if !ok {
+		visitorID = body.VisitorID
+		visitors.Identify(body.IdentifierType, body.Value, visitorID)
+		err = writeVisitors(visitors)
+		if err != nil {
+			return "", err
+		}
+	}
+	err = fakeevents.Append(fakeevents.Event{
+		Kind:            fakeevents.KindIdentifier,
+		VisitorID:       visitorID,
+		IdentifierType:  body.IdentifierType,
+		IdentifierValue: body.Value,
+	})
+	if err != nil {
+		return "", err
+	}
+	return visitorID, nil
+}
+
+// visitorIDForIdentifier looks up the visitor for an identifier, creating a
+// new visitor if the identifier hasn't been seen before
+func visitorIDForIdentifier(identifierType, identifierValue string) (string, error) {
+	visitors, err := readVisitors()
+	if err != nil {
+		return "", err
+	}
+	if visitorID, ok := visitors.VisitorIDFor(identifierType, identifierValue); ok {
+		return visitorID, nil
+	}
+	visitorID, err := newVisitorID()
+	if err != nil {
+		return "", err
+	}
+	visitors.Identify(identifierType, identifierValue, visitorID)
+	err = writeVisitors(visitors)
+	if err != nil {
+		return "", err
+	}
+	return visitorID, nil
 }
 
-func postV4AppIdentifier(*http.Request) (interface{}, error) {
-	return getV4AppVisitorConfig()
+// newVisitorID generates a random v4 UUID
+func newVisitorID() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// visitorAssignments returns a visitor's assignments sorted by split name
+func visitorAssignments(visitorID string) ([]string, map[string]string, error) {
+	globalAssignments, err := readAssignments()
+	if err != nil {
+		return nil, nil, err
+	}
+	visitors, err := readVisitors()
+	if err != nil {
+		return nil, nil, err
+	}
+	assignments := fakeassignments.AssignmentsFor(*globalAssignments, visitors, visitorID)
+	if serverOptions.WeightedAssignments {
+		err = assignByWeight(visitorID, assignments, visitors)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	splitNames := make([]string, 0, len(assignments))
+	for split := range assignments {
+		splitNames = append(splitNames, split)
+	}
+	sort.Strings(splitNames)
+	return splitNames, assignments, nil
 }
 
-func getV1Visitor() (interface{}, error) {
-	assignments, err := fakeassignments.Read()
+// assignByWeight fills in assignments for every split the visitor isn't
+// assigned to yet and persists them so they remain stable
+func assignByWeight(visitorID string, assignments map[string]string, visitors *fakeassignments.Visitors) error {
+	schema, err := readMerged()
+	if err != nil {
+		return err
+	}
+	assigned := false
+	for _, split := range schema.Splits {
+		if _, ok := assignments[split.Name]; ok {
+			continue
+		}
+		variant := fakeassignments.WeightedVariant(visitorID, split.Name, split.Weights)
+		if variant == "" {
+			continue
+		}
+		assignments[split.Name] = variant
+		visitors.Assign(visitorID, split.Name, variant)
+		assigned = true
+	}
+	if !assigned {
+		return nil
+	}
+	return writeVisitors(visitors)
+}
+
+func getV1Visitor(r *http.Request) (interface{}, error) {
+	return v1VisitorFor(mux.Vars(r)["id"])
+}
+
+func getV1IdentifierVisitor(r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	visitorID, err := visitorIDForIdentifier(vars["t"], vars["i"])
 	if err != nil {
 		return nil, err
 	}
-	v1Assignments := make([]v1Assignment, 0, len(*assignments))
-	for split, variant := range *assignments {
+	return v1VisitorFor(visitorID)
+}
+
+func v1VisitorFor(visitorID string) (*v1Visitor, error) {
+	splitNames, assignments, err := visitorAssignments(visitorID)
+	if err != nil {
+		return nil, err
+	}
+	v1Assignments := make([]v1Assignment, 0, len(assignments))
+	for _, split := range splitNames {
 		v1Assignments = append(v1Assignments, v1Assignment{
 			SplitName: split,
-			Variant:   variant,
+			Variant:   assignments[split],
 			Context:   "fake_server",
 			Unsynced:  false,
 		})
 	}
-	return v1Visitor{
-		ID:          "00000000-0000-0000-0000-000000000000",
+	return &v1Visitor{
+		ID:          visitorID,
 		Assignments: v1Assignments,
 	}, nil
 }
 
-func getV4Visitor() (interface{}, error) {
-	assignments, err := fakeassignments.Read()
+func v4VisitorFor(visitorID string) (*v4Visitor, error) {
+	splitNames, assignments, err := visitorAssignments(visitorID)
 	if err != nil {
 		return nil, err
 	}
-	v4Assignments := make([]v4Assignment, 0, len(*assignments))
-	for split, variant := range *assignments {
+	v4Assignments := make([]v4Assignment, 0, len(assignments))
+	for _, split := range splitNames {
 		v4Assignments = append(v4Assignments, v4Assignment{
 			SplitName: split,
-			Variant:   variant,
+			Variant:   assignments[split],
 		})
 	}
-	return v4Visitor{
-		ID:          "00000000-0000-0000-0000-000000000000",
+	return &v4Visitor{
+		ID:          visitorID,
 		Assignments: v4Assignments,
 	}, nil
 }
 
-func getV1VisitorDetail() (interface{}, error) {
-	assignments, err := fakeassignments.Read()
+func getV1VisitorDetail(r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	visitorID, err := visitorIDForIdentifier(vars["t"], vars["i"])
 	if err != nil {
 		return nil, err
 	}
-	v1AssignmentDetails := make([]v1AssignmentDetail, 0, len(*assignments))
-	for split, variant := range *assignments {
-		v1AssignmentDetails = append(v1AssignmentDetails, v1AssignmentDetail{
+	splitNames, assignments, err := visitorAssignments(visitorID)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := readMerged()
+	if err != nil {
+		return nil, err
+	}
+	splitDetails := make(map[string]v1SplitDetail, len(schema.Splits))
+	for _, split := range schema.Splits {
+		splitDetails[split.Name] = v1SplitDetailFor(split)
+	}
+	v1AssignmentDetails := make([]v1AssignmentDetail, 0, len(assignments))
+	for _, split := range splitNames {
+		v1AssignmentDetail := v1AssignmentDetail{
 			SplitLocation:      "somewhere",
 			SplitName:          split,
-			VariantName:        variant,
+			VariantName:        assignments[split],
 			VariantDescription: "a very cool variant",
 			AssignedAt:         "2019-05-02T16:57:36Z",
-		})
+		}
+		if details, ok := splitDetails[split]; ok {
+			if details.Location != "" {
+				v1AssignmentDetail.SplitLocation = details.Location
+			}
+			for _, variantDetail := range details.VariantDetails {
+				if variantDetail.Name == assignments[split] && variantDetail.Description != "" {
+					v1AssignmentDetail.VariantDescription = variantDetail.Description
+				}
+			}
+		}
+		v1AssignmentDetails = append(v1AssignmentDetails, v1AssignmentDetail)
 	}
 	return map[string][]v1AssignmentDetail{"assignment_details": v1AssignmentDetails}, nil
 }
 
 func postV1AssignmentOverride(r *http.Request) error {
 	var assignment v1Assignment
+	var visitorID string
 	contentType := r.Header.Get("content-type")
 	switch {
 	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
@@ -340,29 +617,52 @@ func postV1AssignmentOverride(r *http.Request) error {
 			SplitName: r.PostForm.Get("split_name"),
 			Variant:   r.PostForm.Get("variant"),
 		}
+		visitorID = r.PostForm.Get("visitor_id")
 	case strings.HasPrefix(contentType, "application/json"):
 		requestBytes, err := io.ReadAll(r.Body)
 		if err != nil {
 			return err
 		}
-		err = json.Unmarshal(requestBytes, &assignment)
+		var body struct {
+			v1Assignment
+			VisitorID string `json:"visitor_id"`
+		}
+		err = json.Unmarshal(requestBytes, &body)
 		if err != nil {
 			return err
 		}
+		assignment = body.v1Assignment
+		visitorID = body.VisitorID
 	default:
 		return fmt.Errorf("got unexpected content type %s", contentType)
 	}
-	assignments, err := fakeassignments.Read()
+
+	err := fakeevents.Append(fakeevents.Event{
+		Kind:      fakeevents.KindAssignmentOverride,
+		VisitorID: visitorID,
+		SplitName: assignment.SplitName,
+		Variant:   assignment.Variant,
+	})
 	if err != nil {
 		return err
 	}
 
-	(*assignments)[assignment.SplitName] = assignment.Variant
-	err = fakeassignments.Write(assignments)
+	// Overrides without a visitor apply to everybody, like `testtrack assign`
+	if visitorID == "" {
+		assignments, err := readAssignments()
+		if err != nil {
+			return err
+		}
+		(*assignments)[assignment.SplitName] = assignment.Variant
+		return writeAssignments(assignments)
+	}
+
+	visitors, err := readVisitors()
 	if err != nil {
 		return err
 	}
-	return nil
+	visitors.Assign(visitorID, assignment.SplitName, assignment.Variant)
+	return writeVisitors(visitors)
 }
 
 func postV2AssignmentOverride(r *http.Request) error {
@@ -383,94 +683,154 @@ func postV2AssignmentOverride(r *http.Request) error {
 	default:
 		return fmt.Errorf("got unexpected content type %s", contentType)
 	}
-	storedAssignments, err := fakeassignments.Read()
+	visitors, err := readVisitors()
 	if err != nil {
 		return err
 	}
 
+	visitorID := mux.Vars(r)["v"]
 	for _, assignment := range assignments {
-		(*storedAssignments)[assignment.SplitName] = assignment.Variant
-	}
-	err = fakeassignments.Write(storedAssignments)
-	if err != nil {
-		return err
+		visitors.Assign(visitorID, assignment.SplitName, assignment.Variant)
+		err = fakeevents.Append(fakeevents.Event{
+			Kind:      fakeevents.KindAssignmentOverride,
+			VisitorID: visitorID,
+			SplitName: assignment.SplitName,
+			Variant:   assignment.Variant,
+		})
+		if err != nil {
+			return err
+		}
 	}
-	return nil
+	return writeVisitors(visitors)
 }
 
-func getV1AppVisitorConfig() (interface{}, error) {
-	isplitRegistry, err := getV1SplitRegistry()
-	splitRegistry := isplitRegistry.(map[string]*splits.Weights)
+func getV1AppVisitorConfig(r *http.Request) (interface{}, error) {
+	schema, overrides, err := appBuildSchema(r)
 	if err != nil {
 		return nil, err
 	}
-	ivisitor, err := getV1Visitor()
-	visitor := ivisitor.(v1Visitor)
+	splitRegistry, err := buildV1SplitRegistry(schema)
 	if err != nil {
 		return nil, err
 	}
+	visitor, err := v1VisitorFor(mux.Vars(r)["id"])
+	if err != nil {
+		return nil, err
+	}
+	for i, assignment := range visitor.Assignments {
+		if variant, ok := overrides[assignment.SplitName]; ok {
+			visitor.Assignments[i].Variant = variant
+		}
+	}
 	return v1VisitorConfig{
 		Splits:  splitRegistry,
-		Visitor: visitor,
+		Visitor: *visitor,
 	}, nil
 }
 
-func getV4AppVisitorConfig() (interface{}, error) {
-	isplitRegistry, err := getV4SplitRegistry()
-	splitRegistry := isplitRegistry.(v4SplitRegistry)
+func getV4AppVisitorConfig(r *http.Request) (interface{}, error) {
+	schema, overrides, err := appBuildSchema(r)
 	if err != nil {
 		return nil, err
 	}
-	ivisitor, err := getV4Visitor()
-	visitor := ivisitor.(v4Visitor)
+	return v4AppVisitorConfigFor(mux.Vars(r)["id"], schema, overrides)
+}
+
+// v4AppVisitorConfigFor builds a visitor's config for an app build, forcing
+// the visitor's assignments to the variants overridden for the build
+func v4AppVisitorConfigFor(visitorID string, schema *serializers.Schema, overrides map[string]string) (*v4VisitorConfig, error) {
+	splitRegistry, err := buildV4SplitRegistry(schema)
 	if err != nil {
 		return nil, err
 	}
-	return v4VisitorConfig{
+	visitor, err := v4VisitorFor(visitorID)
+	if err != nil {
+		return nil, err
+	}
+	for i, assignment := range visitor.Assignments {
+		if variant, ok := overrides[assignment.SplitName]; ok {
+			visitor.Assignments[i].Variant = variant
+		}
+	}
+	return &v4VisitorConfig{
 		Splits:                   splitRegistry.Splits,
-		Visitor:                  visitor,
+		Visitor:                  *visitor,
 		ExperienceSamplingWeight: splitRegistry.ExperienceSamplingWeight,
 	}, nil
 }
 
-func getV2AppVisitorConfig() (interface{}, error) {
-	isplitRegistry, err := getV2PlusSplitRegistry()
-	splitRegistry := isplitRegistry.(v2SplitRegistry)
+func getV2AppVisitorConfig(r *http.Request) (interface{}, error) {
+	schema, overrides, err := appBuildSchema(r)
+	if err != nil {
+		return nil, err
+	}
+	splitRegistry, err := buildV2PlusSplitRegistry(schema)
 	if err != nil {
 		return nil, err
 	}
-	ivisitor, err := getV1Visitor()
-	visitor := ivisitor.(v1Visitor)
+	visitor, err := v1VisitorFor(mux.Vars(r)["id"])
 	if err != nil {
 		return nil, err
 	}
+	for i, assignment := range visitor.Assignments {
+		if variant, ok := overrides[assignment.SplitName]; ok {
+			visitor.Assignments[i].Variant = variant
+		}
+	}
 	return v2VisitorConfig{
 		Splits:                   splitRegistry.Splits,
-		Visitor:                  visitor,
+		Visitor:                  *visitor,
 		ExperienceSamplingWeight: splitRegistry.ExperienceSamplingWeight,
 	}, nil
 }
 
-func getV1SplitDetail() (interface{}, error) {
-	return v1SplitDetail{
-		Name:               "something",
-		Hypothesis:         "my hypothesis",
-		AssignmentCriteria: "assignment criteria go here...",
-		Description:        "split description...",
-		Owner:              "owner",
-		Location:           "location",
-		Platform:           "platform",
-		VariantDetails: []v1VariantDetail{
-			{
-				Name:          "variant_a",
-				Description:   "this is a fake description",
-				ScreenshotURL: "https://example.org/a",
-			},
-			{
-				Name:          "variant_b",
-				Description:   "this is another fake description",
-				ScreenshotURL: "https://example.org/b",
-			},
-		},
-	}, nil
+func getV1SplitDetail(r *http.Request) (interface{}, error) {
+	name := mux.Vars(r)["id"]
+	schema, err := readMerged()
+	if err != nil {
+		return nil, err
+	}
+	for _, split := range schema.Splits {
+		if split.Name == name {
+			return v1SplitDetailFor(split), nil
+		}
+	}
+	return nil, fmt.Errorf("split %s: %w", name, errNotFound)
+}
+
+// v1SplitDetailFor documents every variant of a split, whether or not the
+// split's details describe it
+func v1SplitDetailFor(split serializers.SchemaSplit) v1SplitDetail {
+	details := split.Details
+	if details == nil {
+		details = &serializers.SplitDetails{}
+	}
+	variantDetails := make(map[string]serializers.VariantDetail, len(details.VariantDetails))
+	for _, variantDetail := range details.VariantDetails {
+		variantDetails[variantDetail.Name] = variantDetail
+	}
+	variants := make([]string, 0, len(split.Weights))
+	for variant := range split.Weights {
+		variants = append(variants, variant)
+	}
+	sort.Strings(variants)
+
+	result := v1SplitDetail{
+		Name:               split.Name,
+		Hypothesis:         details.Hypothesis,
+		AssignmentCriteria: details.AssignmentCriteria,
+		Description:        details.Description,
+		Owner:              split.Owner,
+		Location:           details.Location,
+		Platform:           details.Platform,
+		VariantDetails:     make([]v1VariantDetail, 0, len(variants)),
+	}
+	for _, variant := range variants {
+		result.VariantDetails = append(result.VariantDetails, v1VariantDetail{
+			Name:          variant,
+			Description:   variantDetails[variant].Description,
+			ScreenshotURL: variantDetails[variant].ScreenshotURL,
+		})
+	}
+	return result
 }
@@ -2,7 +2,9 @@ package fakeserver
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/http"
@@ -11,16 +13,44 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/Betterment/testtrack-cli/recordings"
 	"github.com/gorilla/mux"
 	"github.com/rs/cors"
 )
 
 // Force every biz logic operation acquire the same lock so nobody's reading or
-// writing inconsistent state from/to the filesystem
+// writing inconsistent state in memory or on the filesystem
 var mutex sync.Mutex
 
 var logger *log.Logger
 
+// errNotFound and errBadRequest are wrapped by handler errors that should be
+// 404s and 400s rather than 500s
+var errNotFound = errors.New("not found")
+var errBadRequest = errors.New("bad request")
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, errNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errBadRequest):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// Options configures optional fake server behavior
+type Options struct {
+	// WeightedAssignments assigns visitors without an override to a variant
+	// according to the split's weights and persists the assignment
+	WeightedAssignments bool
+	// Record receives a JSON line for every request handled and its response,
+	// for replaying with recordings.Replay
+	Record io.Writer
+}
+
+var serverOptions Options
+
 type server struct {
 	router *mux.Router
 }
@@ -70,8 +100,10 @@ func createCors() *cors.Cors {
 }
 
 // Start the server
-func Start(port int) {
+func Start(port int, options Options) {
+	serverOptions = options
 	handler := createHandler()
+	go current.watch()
 
 	listenOn := fmt.Sprintf("127.0.0.1:%d", port)
 	logger.Printf("testtrack server listening on %s", listenOn)
@@ -80,6 +112,7 @@ func Start(port int) {
 
 func createHandler() http.Handler {
 	logger = log.New(os.Stdout, "", log.LstdFlags)
+	current = loadState()
 
 	r := mux.NewRouter()
 
@@ -88,17 +121,21 @@ func createHandler() http.Handler {
 
 	r.Use(loggingMiddleware)
 
-	return createCors().Handler(r)
+	handler := createCors().Handler(r)
+	if serverOptions.Record != nil {
+		handler = recordings.NewRecorder(serverOptions.Record).Handler(handler)
+	}
+	return handler
 }
 
-func (s *server) handleGet(pattern string, responseFunc func() (interface{}, error)) {
+func (s *server) handleGet(pattern string, responseFunc func(*http.Request) (interface{}, error)) {
 	s.router.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
 		mutex.Lock()
-		result, err := responseFunc()
+		result, err := responseFunc(r)
 		mutex.Unlock()
 		if err != nil {
 			logger.Println(err)
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(errorStatus(err))
 			return
 		}
 		bytes, err := json.Marshal(result)
@@ -119,7 +156,7 @@ func (s *server) handlePost(pattern string, actionFunc func(*http.Request) (inte
 		mutex.Unlock()
 		if err != nil {
 			logger.Println(err)
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(errorStatus(err))
 			return
 		}
 		if result == nil {
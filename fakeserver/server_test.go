@@ -13,6 +13,10 @@ import (
 	"testing"
 
 	"github.com/Betterment/testtrack-cli/fakeassignments"
+	"github.com/Betterment/testtrack-cli/fakeevents"
+	"github.com/Betterment/testtrack-cli/recordings"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/splits"
 
 	"encoding/json"
 
@@ -27,6 +31,14 @@ splits:
   weights:
     control: 60
     treatment: 40
+  owner: growth
+  details:
+    hypothesis: the new flow converts better
+    location: checkout
+    variant_details:
+    - name: treatment
+      description: the new flow
+      screenshot_url: https://example.org/treatment.png
 - name: test.test2_experiment
   weights:
     control: 60
@@ -174,6 +186,41 @@ func TestSplitRegistry(t *testing.T) {
 	})
 }
 
+func TestSplitDetail(t *testing.T) {
+	t.Run("it serves the split's details from the schema", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h := createHandler()
+
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/split_details/test.test_experiment", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		detail := v1SplitDetail{}
+		err := json.Unmarshal(w.Body.Bytes(), &detail)
+		require.Nil(t, err)
+
+		require.Equal(t, v1SplitDetail{
+			Name:       "test.test_experiment",
+			Hypothesis: "the new flow converts better",
+			Owner:      "growth",
+			Location:   "checkout",
+			VariantDetails: []v1VariantDetail{
+				{Name: "control"},
+				{Name: "treatment", Description: "the new flow", ScreenshotURL: "https://example.org/treatment.png"},
+			},
+		}, detail)
+	})
+
+	t.Run("it 404s for unknown splits", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h := createHandler()
+
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/split_details/test.missing_experiment", nil))
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
 func TestVisitorConfig(t *testing.T) {
 	t.Run("it loads visitor config v4", func(t *testing.T) {
 		w := httptest.NewRecorder()
@@ -373,9 +420,435 @@ func TestPersistAssignmentV2(t *testing.T) {
 
 		require.Equal(t, http.StatusNoContent, w.Code)
 
+		visitors, err := fakeassignments.ReadVisitors()
+		require.Nil(t, err)
+		require.Equal(t, "control", visitors.Assignments["1"]["test.test_experiment"])
+		require.Equal(t, "treatment", visitors.Assignments["1"]["test.test2_experiment"])
+	})
+}
+
+func TestPerVisitorAssignments(t *testing.T) {
+	t.Run("it keeps overrides for different visitors separate", func(t *testing.T) {
+		h := createHandler()
+
+		for visitorID, variant := range map[string]string{"visitor_a": "control", "visitor_b": "treatment"} {
+			data, err := json.Marshal(v2AssignmentOverrideRequestBody{
+				Assignments: []v1Assignment{{SplitName: "test.test2_experiment", Variant: variant}},
+			})
+			require.Nil(t, err)
+
+			request := httptest.NewRequest("POST", "/api/v2/visitors/"+visitorID+"/assignment_overrides", bytes.NewReader(data))
+			request.Header.Add("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, request)
+			require.Equal(t, http.StatusNoContent, w.Code)
+		}
+
+		for visitorID, variant := range map[string]string{"visitor_a": "control", "visitor_b": "treatment"} {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/visitors/"+visitorID, nil))
+			require.Equal(t, http.StatusOK, w.Code)
+
+			visitor := v1Visitor{}
+			err := json.Unmarshal(w.Body.Bytes(), &visitor)
+			require.Nil(t, err)
+
+			assignments := map[string]string{}
+			for _, assignment := range visitor.Assignments {
+				assignments[assignment.SplitName] = assignment.Variant
+			}
+			require.Equal(t, visitorID, visitor.ID)
+			require.Equal(t, variant, assignments["test.test2_experiment"])
+			require.Equal(t, "true", assignments["something_something_enabled"])
+		}
+	})
+
+	t.Run("it resolves identifiers to the visitor that claimed them", func(t *testing.T) {
+		h := createHandler()
+
+		body := []byte(`{"identifier_type":"user_id","value":"42","visitor_id":"visitor_c"}`)
+		request := httptest.NewRequest("POST", "/api/v1/identifier", bytes.NewReader(body))
+		request.Header.Add("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, request)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/identifier_types/user_id/identifiers/42/visitor", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		visitor := v1Visitor{}
+		err := json.Unmarshal(w.Body.Bytes(), &visitor)
+		require.Nil(t, err)
+		require.Equal(t, "visitor_c", visitor.ID)
+	})
+}
+
+func TestWeightedAssignments(t *testing.T) {
+	serverOptions = Options{WeightedAssignments: true}
+	defer func() { serverOptions = Options{} }()
+
+	t.Run("it assigns unassigned splits by weight and keeps them stable", func(t *testing.T) {
+		h := createHandler()
+
+		variantsFor := func(visitorID string) map[string]string {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/visitors/"+visitorID, nil))
+			require.Equal(t, http.StatusOK, w.Code)
+
+			visitor := v1Visitor{}
+			err := json.Unmarshal(w.Body.Bytes(), &visitor)
+			require.Nil(t, err)
+
+			variants := map[string]string{}
+			for _, assignment := range visitor.Assignments {
+				variants[assignment.SplitName] = assignment.Variant
+			}
+			return variants
+		}
+
+		first := variantsFor("weighted_visitor")
+		require.Contains(t, []string{"control", "treatment"}, first["test.test_experiment"])
+		require.Contains(t, []string{"control", "treatment"}, first["test.json_experiment"])
+		require.Equal(t, first, variantsFor("weighted_visitor"))
+
+		visitors, err := fakeassignments.ReadVisitors()
+		require.Nil(t, err)
+		require.Equal(t, first["test.json_experiment"], visitors.Assignments["weighted_visitor"]["test.json_experiment"])
+	})
+
+	t.Run("it buckets visitors consistently with TestTrack", func(t *testing.T) {
+		weights := splits.Weights{"control": 60, "treatment": 40}
+		require.Equal(t, "control", weights.VariantForBucket(0))
+		require.Equal(t, "control", weights.VariantForBucket(59))
+		require.Equal(t, "treatment", weights.VariantForBucket(60))
+		require.Equal(t, "treatment", weights.VariantForBucket(99))
+
+		// Buckets computed by the Ruby client's
+		// Digest::MD5.hexdigest(split_name + visitor_id).slice(0, 8).to_i(16) % 100,
+		// each pinned by weights whose boundary sits on the bucket
+		buckets := []struct {
+			split   string
+			visitor string
+			bucket  int
+		}{
+			{"blue_button", "00000000-0000-0000-0000-000000000000", 66},
+			{"logoSize", "11111111-1111-1111-1111-111111111111", 84},
+			{"logoSize", "b05a1c58-2e9a-4d0e-b2d6-4b1f5e2b8d1f", 68},
+			{"test.test_experiment", "weighted_visitor", 10},
+			{"test.json_experiment", "weighted_visitor", 15},
+		}
+		for _, b := range buckets {
+			below := splits.Weights{"control": b.bucket, "treatment": 100 - b.bucket}
+			require.Equal(t, "treatment", fakeassignments.WeightedVariant(b.visitor, b.split, below), "%s %s", b.split, b.visitor)
+			above := splits.Weights{"control": b.bucket + 1, "treatment": 99 - b.bucket}
+			require.Equal(t, "control", fakeassignments.WeightedVariant(b.visitor, b.split, above), "%s %s", b.split, b.visitor)
+		}
+	})
+}
+
+func TestAppVersionOverrides(t *testing.T) {
+	firstBadVersion := "1.2"
+	fixedVersion := "1.4.1"
+	overrideTo := "control"
+	completedVersion := "2.0"
+	otherCompletedVersion := "9.0"
+	schema := &serializers.Schema{
+		Splits: []serializers.SchemaSplit{
+			{Name: "test.test_experiment", Weights: map[string]int{"control": 60, "treatment": 40}},
+			{Name: "test.new_flow_enabled", Weights: map[string]int{"false": 0, "true": 100}},
+			{Name: "test.other_flow_enabled", Weights: map[string]int{"false": 0, "true": 100}},
+			{Name: "other.new_flow_enabled", Weights: map[string]int{"false": 0, "true": 100}},
+		},
+		RemoteKills: []serializers.RemoteKill{
+			{Split: "test.test_experiment", Reason: "crash", OverrideTo: &overrideTo, FirstBadVersion: &firstBadVersion, FixedVersion: &fixedVersion},
+		},
+		FeatureCompletions: []serializers.FeatureCompletion{
+			{FeatureGate: "test.new_flow_enabled", Version: &completedVersion},
+			{FeatureGate: "other.new_flow_enabled", Version: &otherCompletedVersion},
+		},
+	}
+
+	t.Run("it forces feature gates to false before their feature completion", func(t *testing.T) {
+		require.Equal(t, map[string]string{"test.new_flow_enabled": "false"}, appVersionOverrides(schema, "test", "1.1.9"))
+	})
+
+	t.Run("it applies remote kills from the first bad version", func(t *testing.T) {
+		require.Equal(t, "control", appVersionOverrides(schema, "test", "1.2")["test.test_experiment"])
+		require.Equal(t, "control", appVersionOverrides(schema, "test", "1.4.0")["test.test_experiment"])
+	})
+
+	t.Run("it stops applying remote kills at the fixed version", func(t *testing.T) {
+		require.NotContains(t, appVersionOverrides(schema, "test", "1.4.1"), "test.test_experiment")
+	})
+
+	t.Run("it stops forcing feature gates once the version is complete", func(t *testing.T) {
+		require.Empty(t, appVersionOverrides(schema, "test", "2.0.0"))
+	})
+
+	t.Run("it only applies the app's own kills and completions", func(t *testing.T) {
+		require.Equal(t, map[string]string{"other.new_flow_enabled": "false"}, appVersionOverrides(schema, "other", "2.0.0"))
+	})
+
+	t.Run("it compares pre-release and long versions", func(t *testing.T) {
+		require.Equal(t, "control", appVersionOverrides(schema, "test", "1.2.3-beta")["test.test_experiment"])
+		require.NotContains(t, appVersionOverrides(schema, "test", "1.4.1.1"), "test.test_experiment")
+	})
+
+	t.Run("it doesn't override versions it can't parse", func(t *testing.T) {
+		require.Empty(t, appVersionOverrides(schema, "test", "banana"))
+	})
+
+	t.Run("it serves malformed versions and build timestamps in the path", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h := createHandler()
+
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v4/apps/foo/versions/banana/builds/yesterday/visitors/00000000-0000-0000-0000-000000000000/config", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v4/builds/yesterday/split_registry", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestAdmin(t *testing.T) {
+	configDir := t.TempDir()
+	require.Nil(t, os.MkdirAll(filepath.Join(configDir, "schemas"), 0755))
+	require.Nil(t, os.WriteFile(filepath.Join(configDir, "schemas", "a.yml"), []byte(testSchema), 0644))
+	t.Setenv("TESTTRACK_FAKE_SERVER_CONFIG_DIR", configDir)
+
+	postAdmin := func(path string, body interface{}) *httptest.ResponseRecorder {
+		data, err := json.Marshal(body)
+		require.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", path, bytes.NewReader(data))
+		request.Header.Add("Content-Type", "application/json")
+		createHandler().ServeHTTP(w, request)
+		return w
+	}
+
+	t.Run("it serves the admin page", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		createHandler().ServeHTTP(w, httptest.NewRequest("GET", "/admin", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Header().Get("Content-Type"), "text/html")
+		require.Contains(t, w.Body.String(), "/admin/assignments")
+	})
+
+	t.Run("it serves the merged schema", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		createHandler().ServeHTTP(w, httptest.NewRequest("GET", "/admin/schema", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		schema := serializers.Schema{}
+		require.Nil(t, json.Unmarshal(w.Body.Bytes(), &schema))
+		require.Len(t, schema.Splits, 2)
+		require.Equal(t, "test.test_experiment", schema.Splits[0].Name)
+	})
+
+	t.Run("it sets and removes assignments", func(t *testing.T) {
+		w := postAdmin("/admin/assignments", adminAssignmentRequestBody{SplitName: "test.test_experiment", Variant: "treatment"})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = postAdmin("/admin/assignments", adminAssignmentRequestBody{SplitName: "test.test2_experiment", Variant: "control", VisitorID: "visitor_a"})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		assignments := adminAssignments{}
+		require.Nil(t, json.Unmarshal(w.Body.Bytes(), &assignments))
+		require.Equal(t, map[string]string{"test.test_experiment": "treatment"}, assignments.Assignments)
+		require.Equal(t, map[string]map[string]string{"visitor_a": {"test.test2_experiment": "control"}}, assignments.Visitors)
+
+		w = postAdmin("/admin/assignments", adminAssignmentRequestBody{SplitName: "test.test_experiment"})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = httptest.NewRecorder()
+		createHandler().ServeHTTP(w, httptest.NewRequest("GET", "/admin/assignments", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		assignments = adminAssignments{}
+		require.Nil(t, json.Unmarshal(w.Body.Bytes(), &assignments))
+		require.Empty(t, assignments.Assignments)
+		require.Len(t, assignments.Visitors, 1)
+	})
+
+	t.Run("it rejects variants that aren't in the schema", func(t *testing.T) {
+		w := postAdmin("/admin/assignments", adminAssignmentRequestBody{SplitName: "test.test_experiment", Variant: "bogus"})
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it rejects form posts", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/admin/reset", strings.NewReader(""))
+		request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		createHandler().ServeHTTP(w, request)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it resets state", func(t *testing.T) {
+		w := postAdmin("/admin/assignments", adminAssignmentRequestBody{SplitName: "test.test_experiment", Variant: "control"})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = postAdmin("/admin/reset", struct{}{})
+		require.Equal(t, http.StatusNoContent, w.Code)
+
 		assignments, err := fakeassignments.Read()
 		require.Nil(t, err)
-		require.Equal(t, "control", (*assignments)["test.test_experiment"])
-		require.Equal(t, "treatment", (*assignments)["test.test2_experiment"])
+		require.Empty(t, *assignments)
+		visitors, err := fakeassignments.ReadVisitors()
+		require.Nil(t, err)
+		require.Empty(t, visitors.Assignments)
+		require.Empty(t, visitors.Identifiers)
+	})
+}
+
+func TestEventRecording(t *testing.T) {
+	configDir := t.TempDir()
+	require.Nil(t, os.MkdirAll(filepath.Join(configDir, "schemas"), 0755))
+	require.Nil(t, os.WriteFile(filepath.Join(configDir, "schemas", "a.yml"), []byte(testSchema), 0644))
+	t.Setenv("TESTTRACK_FAKE_SERVER_CONFIG_DIR", configDir)
+
+	postForm := func(path string, data url.Values) {
+		w := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", path, strings.NewReader(data.Encode()))
+		request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		createHandler().ServeHTTP(w, request)
+		require.Equal(t, http.StatusNoContent, w.Code)
+	}
+
+	t.Run("it records assignment events, identifiers and overrides", func(t *testing.T) {
+		postForm("/api/v1/assignment_override", url.Values{
+			"split_name": {"test.test_experiment"},
+			"variant":    {"treatment"},
+			"visitor_id": {"visitor_a"},
+		})
+		postForm("/api/v1/assignment_event", url.Values{
+			"split_name": {"test.test_experiment"},
+			"visitor_id": {"visitor_a"},
+			"context":    {"home_page"},
+		})
+
+		data, err := json.Marshal(v1IdentifierRequestBody{IdentifierType: "user_id", Value: "42", VisitorID: "visitor_a"})
+		require.Nil(t, err)
+		w := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/api/v1/identifier", bytes.NewReader(data))
+		request.Header.Add("Content-Type", "application/json")
+		createHandler().ServeHTTP(w, request)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = httptest.NewRecorder()
+		createHandler().ServeHTTP(w, httptest.NewRequest("GET", "/admin/events?visitor=visitor_a", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var body struct {
+			Events []fakeevents.Event `json:"events"`
+		}
+		require.Nil(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.Len(t, body.Events, 3)
+		require.Equal(t, fakeevents.KindAssignmentOverride, body.Events[0].Kind)
+		require.Equal(t, fakeevents.KindAssignmentEvent, body.Events[1].Kind)
+		require.Equal(t, "treatment", body.Events[1].Variant)
+		require.Equal(t, "home_page", body.Events[1].Context)
+		require.Equal(t, fakeevents.KindIdentifier, body.Events[2].Kind)
+		require.Equal(t, "42", body.Events[2].IdentifierValue)
+	})
+
+	t.Run("it filters events by split", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		createHandler().ServeHTTP(w, httptest.NewRequest("GET", "/admin/events?split=test.test2_experiment", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"events": []}`, w.Body.String())
+	})
+
+	t.Run("it 400s for malformed since params", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		createHandler().ServeHTTP(w, httptest.NewRequest("GET", "/admin/events?since=yesterday", nil))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestRecord(t *testing.T) {
+	t.Run("it records requests and responses", func(t *testing.T) {
+		var recording bytes.Buffer
+		serverOptions.Record = &recording
+		defer func() { serverOptions.Record = nil }()
+
+		w := httptest.NewRecorder()
+		createHandler().ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/split_registry", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var exchange recordings.Exchange
+		require.Nil(t, json.Unmarshal(recording.Bytes(), &exchange))
+		require.Equal(t, "GET", exchange.Request.Method)
+		require.Equal(t, "/api/v2/split_registry", exchange.Request.URL)
+		require.Equal(t, http.StatusOK, exchange.Response.Status)
+		require.Equal(t, w.Body.String(), exchange.Response.Body)
+	})
+}
+
+func TestReload(t *testing.T) {
+	configDir := t.TempDir()
+	schemaPath := filepath.Join(configDir, "schemas", "a.yml")
+	require.Nil(t, os.MkdirAll(filepath.Dir(schemaPath), 0755))
+	require.Nil(t, os.WriteFile(schemaPath, []byte(testSchema), 0644))
+	t.Setenv("TESTTRACK_FAKE_SERVER_CONFIG_DIR", configDir)
+
+	h := createHandler()
+	getRegistry := func() v2SplitRegistry {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/split_registry", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		registry := v2SplitRegistry{}
+		require.Nil(t, json.Unmarshal(w.Body.Bytes(), &registry))
+		return registry
+	}
+
+	t.Run("it serves requests from memory", func(t *testing.T) {
+		require.Nil(t, os.WriteFile(schemaPath, []byte(otherTestSchema), 0644))
+
+		require.Contains(t, getRegistry().Splits, "test.test_experiment")
+	})
+
+	t.Run("it reloads changed schemas", func(t *testing.T) {
+		require.Equal(t, []string{schemaPath}, current.reloadChanged())
+
+		registry := getRegistry()
+		require.Contains(t, registry.Splits, "test.json_experiment")
+		require.NotContains(t, registry.Splits, "test.test_experiment")
+	})
+
+	t.Run("it reloads assignments changed by the CLI", func(t *testing.T) {
+		require.Nil(t, fakeassignments.Write(&map[string]string{"test.json_experiment": "treatment"}))
+		require.Equal(t, []string{filepath.Join(configDir, "assignments.yml")}, current.reloadChanged())
+
+		assignments, err := readAssignments()
+		require.Nil(t, err)
+		require.Equal(t, map[string]string{"test.json_experiment": "treatment"}, *assignments)
+	})
+
+	t.Run("it doesn't mistake its own writes for changes", func(t *testing.T) {
+		data, err := json.Marshal(adminAssignmentRequestBody{SplitName: "test.json_experiment", Variant: "control"})
+		require.Nil(t, err)
+		w := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/admin/assignments", bytes.NewReader(data))
+		request.Header.Add("Content-Type", "application/json")
+		h.ServeHTTP(w, request)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		require.Empty(t, current.reloadChanged())
+	})
+
+	t.Run("it notices removed schemas", func(t *testing.T) {
+		require.Nil(t, os.Remove(schemaPath))
+		require.Equal(t, []string{schemaPath}, current.reloadChanged())
+
+		require.Empty(t, getRegistry().Splits)
 	})
 }
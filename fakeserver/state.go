@@ -0,0 +1,243 @@
+package fakeserver
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/fakeassignments"
+	"github.com/Betterment/testtrack-cli/paths"
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/Betterment/testtrack-cli/serializers"
+)
+
+// watchInterval is how often the server checks its files for changes
+const watchInterval = time.Second
+
+// fingerprint identifies a version of a file well enough to notice edits
+type fingerprint struct {
+	modTime time.Time
+	size    int64
+}
+
+// state is the fake server's in-memory copy of the linked schemas and
+// assignment files. Handlers read and write it instead of the filesystem,
+// writes go through to disk so the CLI sees them, and a watcher reloads it
+// when the files are changed by anything else. Like every other handler
+// operation, access must hold mutex.
+type state struct {
+	configDir string
+
+	schema    *serializers.Schema
+	schemaErr error
+
+	assignments    map[string]string
+	assignmentsErr error
+	visitors       *fakeassignments.Visitors
+	visitorsErr    error
+
+	fingerprints map[string]fingerprint
+}
+
+var current *state
+
+func loadState() *state {
+	s := &state{fingerprints: map[string]fingerprint{}}
+	configDir, err := paths.FakeServerConfigDir()
+	if err != nil {
+		s.schemaErr, s.assignmentsErr, s.visitorsErr = err, err, err
+		return s
+	}
+	s.configDir = *configDir
+	s.reloadSchema()
+	s.reloadAssignments()
+	s.reloadVisitors()
+	s.fingerprints = s.scan()
+	return s
+}
+
+func (s *state) reloadSchema() {
+	var conflicts []schema.Conflict
+	s.schema, conflicts, s.schemaErr = schema.ReadMergedWithConflicts()
+	for _, conflict := range conflicts {
+		logger.Printf("warning - linked schemas %s define %s %s differently (%s), using the first",
+			strings.Join(conflict.Files, ", "), conflict.Kind, conflict.Name, conflict.Detail)
+	}
+}
+
+func (s *state) reloadAssignments() {
+	assignments, err := fakeassignments.Read()
+	s.assignmentsErr = err
+	if err == nil {
+		s.assignments = *assignments
+	}
+}
+
+func (s *state) reloadVisitors() {
+	s.visitors, s.visitorsErr = fakeassignments.ReadVisitors()
+}
+
+func (s *state) assignmentsPath() string {
+	return filepath.Join(s.configDir, "assignments.yml")
+}
+
+func (s *state) visitorsPath() string {
+	return filepath.Join(s.configDir, "visitors.yml")
+}
+
+// scan fingerprints the linked schemas, following their symlinks, and the
+// assignment files
+func (s *state) scan() map[string]fingerprint {
+	fingerprints := map[string]fingerprint{}
+	linkPaths, _ := filepath.Glob(filepath.Join(s.configDir, "schemas", "*.*"))
+	for _, path := range append(linkPaths, s.assignmentsPath(), s.visitorsPath()) {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue // Broken symlinks are skipped by schema.ReadMerged too
+		}
+		fingerprints[path] = fingerprint{modTime: info.ModTime(), size: info.Size()}
+	}
+	return fingerprints
+}
+
+// reloadChanged reloads whatever changed on disk since the last scan and
+// returns the paths that changed
+func (s *state) reloadChanged() []string {
+	fingerprints := s.scan()
+	changed := []string{}
+	for path, fp := range fingerprints {
+		if s.fingerprints[path] != fp {
+			changed = append(changed, path)
+		}
+	}
+	for path := range s.fingerprints {
+		if _, ok := fingerprints[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	s.fingerprints = fingerprints
+
+	schemaChanged := false
+	for _, path := range changed {
+		switch path {
+		case s.assignmentsPath():
+			s.reloadAssignments()
+		case s.visitorsPath():
+			s.reloadVisitors()
+		default:
+			schemaChanged = true
+		}
+	}
+	if schemaChanged {
+		s.reloadSchema()
+	}
+	return changed
+}
+
+// watch reloads changed files until the process exits, logging each change
+func (s *state) watch() {
+	for range time.Tick(watchInterval) {
+		mutex.Lock()
+		changed := s.reloadChanged()
+		for _, path := range changed {
+			logger.Printf("reloaded %s", path)
+		}
+		if len(changed) != 0 && s.schemaErr != nil {
+			logger.Printf("error - couldn't load linked schemas: %s", s.schemaErr)
+		}
+		mutex.Unlock()
+	}
+}
+
+// remember fingerprints a file the server just wrote so the watcher doesn't
+// mistake it for an outside change
+func (s *state) remember(path string) {
+	info, err := os.Stat(path)
+	if err == nil {
+		s.fingerprints[path] = fingerprint{modTime: info.ModTime(), size: info.Size()}
+	}
+}
+
+// readMerged returns a copy of the merged linked schemas
+func readMerged() (*serializers.Schema, error) {
+	if current.schemaErr != nil {
+		return nil, current.schemaErr
+	}
+	return copySchema(current.schema), nil
+}
+
+// readAssignments returns a copy of the overrides that apply to every visitor
+func readAssignments() (*map[string]string, error) {
+	if current.assignmentsErr != nil {
+		return nil, current.assignmentsErr
+	}
+	assignments := copyMap(current.assignments)
+	return &assignments, nil
+}
+
+// writeAssignments replaces the overrides that apply to every visitor
+func writeAssignments(assignments *map[string]string) error {
+	err := fakeassignments.Write(assignments)
+	if err != nil {
+		return err
+	}
+	current.assignments, current.assignmentsErr = copyMap(*assignments), nil
+	current.remember(current.assignmentsPath())
+	return nil
+}
+
+// readVisitors returns a copy of the per-visitor state
+func readVisitors() (*fakeassignments.Visitors, error) {
+	if current.visitorsErr != nil {
+		return nil, current.visitorsErr
+	}
+	return &fakeassignments.Visitors{
+		Assignments: copyNested(current.visitors.Assignments),
+		Identifiers: copyNested(current.visitors.Identifiers),
+	}, nil
+}
+
+// writeVisitors replaces the per-visitor state
+func writeVisitors(visitors *fakeassignments.Visitors) error {
+	err := fakeassignments.WriteVisitors(visitors)
+	if err != nil {
+		return err
+	}
+	current.visitors = &fakeassignments.Visitors{
+		Assignments: copyNested(visitors.Assignments),
+		Identifiers: copyNested(visitors.Identifiers),
+	}
+	current.visitorsErr = nil
+	current.remember(current.visitorsPath())
+	return nil
+}
+
+// copySchema copies a schema deeply enough that handlers can reweight its
+// splits without changing the cached one
+func copySchema(s *serializers.Schema) *serializers.Schema {
+	copied := *s
+	copied.Splits = append([]serializers.SchemaSplit(nil), s.Splits...)
+	copied.IdentifierTypes = append([]serializers.IdentifierType(nil), s.IdentifierTypes...)
+	copied.RemoteKills = append([]serializers.RemoteKill(nil), s.RemoteKills...)
+	copied.FeatureCompletions = append([]serializers.FeatureCompletion(nil), s.FeatureCompletions...)
+	return &copied
+}
+
+func copyMap(m map[string]string) map[string]string {
+	copied := make(map[string]string, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
+
+func copyNested(m map[string]map[string]string) map[string]map[string]string {
+	copied := make(map[string]map[string]string, len(m))
+	for key, inner := range m {
+		copied[key] = copyMap(inner)
+	}
+	return copied
+}
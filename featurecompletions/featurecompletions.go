@@ -13,10 +13,11 @@ type FeatureCompletion struct {
 	migrationVersion *string
 	featureGate      *string
 	version          *string
+	expiresAt        *string
 }
 
 // New returns a migration object
-func New(featureGate *string, version *string) (migrations.IMigration, error) {
+func New(featureGate, version, expiresAt *string) (migrations.IMigration, error) {
 	migrationVersion, err := migrations.GenerateMigrationVersion()
 	if err != nil {
 		return nil, err
@@ -26,6 +27,7 @@ func New(featureGate *string, version *string) (migrations.IMigration, error) {
 		migrationVersion: migrationVersion,
 		featureGate:      featureGate,
 		version:          version,
+		expiresAt:        expiresAt,
 	}, nil
 }
 
@@ -35,6 +37,7 @@ func FromFile(migrationVersion *string, serializable *serializers.FeatureComplet
 		migrationVersion: migrationVersion,
 		featureGate:      &serializable.FeatureGate,
 		version:          serializable.Version,
+		expiresAt:        serializable.ExpiresAt,
 	}
 }
 
@@ -50,6 +53,11 @@ func (f *FeatureCompletion) Validate() error {
 		return err
 	}
 
+	err = validations.OptionalDate("expires_at", f.expiresAt)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -77,15 +85,19 @@ func (f *FeatureCompletion) SyncPath() string {
 	return "api/v2/migrations/app_feature_completion"
 }
 
-// Serializable returns a JSON serializable representation
+// Serializable returns a JSON serializable representation. Expiry is
+// enforced by the CLI, so it isn't sent to the server.
 func (f *FeatureCompletion) Serializable() interface{} {
-	return f.serializable()
+	serializable := f.serializable()
+	serializable.ExpiresAt = nil
+	return serializable
 }
 
 func (f *FeatureCompletion) serializable() *serializers.FeatureCompletion {
 	return &serializers.FeatureCompletion{
 		FeatureGate: *f.featureGate,
 		Version:     f.version,
+		ExpiresAt:   f.expiresAt,
 	}
 }
 
@@ -0,0 +1,126 @@
+package formatupgrades
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"gopkg.in/yaml.v2"
+)
+
+// steps upgrade a migration file from the serializer version at their index
+// plus one to the next version. A nil step means files only need relabeling,
+// e.g. because version 2 only added optional fields and migration types.
+var steps = []func(*serializers.MigrationFile) error{
+	nil, // 1 -> 2
+}
+
+var serializerVersionRegex = regexp.MustCompile(`(?m)^serializer_version:.*$`)
+
+// Result lists the files an upgrade rewrote
+type Result struct {
+	Migrations []string `json:"migrations"`
+	Schema     string   `json:"schema,omitempty"`
+}
+
+// Upgrade rewrites migration files in testtrack/migrate and the schema at the
+// current serializer version. Files already at the current version are left
+// untouched, and files from newer versions are rejected before anything is
+// written.
+func Upgrade() (*Result, error) {
+	files, err := os.ReadDir("testtrack/migrate")
+	if err != nil {
+		return nil, err
+	}
+
+	upgraded := make(map[string][]byte)
+	filenames := []string{}
+	for _, file := range files {
+		if strings.HasPrefix(file.Name(), ".") {
+			continue // Skip hidden files
+		}
+		filename := path.Join("testtrack/migrate", file.Name())
+		fileBytes, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		var migrationFile serializers.MigrationFile
+		err = yaml.Unmarshal(fileBytes, &migrationFile)
+		if err != nil {
+			return nil, err
+		}
+		err = serializers.CheckSerializerVersion(filename, migrationFile.SerializerVersion)
+		if err != nil {
+			return nil, err
+		}
+		if migrationFile.SerializerVersion == serializers.SerializerVersion {
+			continue
+		}
+
+		transformed := false
+		for version := max(migrationFile.SerializerVersion, 1); version < serializers.SerializerVersion; version++ {
+			if steps[version-1] == nil {
+				continue
+			}
+			err = steps[version-1](&migrationFile)
+			if err != nil {
+				return nil, err
+			}
+			transformed = true
+		}
+
+		// Files that only need relabeling keep their formatting, so an upgrade
+		// doesn't churn the whole migration history
+		if transformed {
+			migrationFile.SerializerVersion = serializers.SerializerVersion
+			upgraded[filename], err = yaml.Marshal(&migrationFile)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			upgraded[filename] = relabel(fileBytes)
+		}
+		filenames = append(filenames, filename)
+	}
+
+	var committedSchema *serializers.Schema
+	schemaPath, schemaExists := schema.Path()
+	if schemaExists {
+		committedSchema, err = schema.ReadCommitted()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &Result{Migrations: filenames}
+	for _, filename := range filenames {
+		err = os.WriteFile(filename, upgraded[filename], 0644)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if committedSchema != nil && committedSchema.SerializerVersion < serializers.SerializerVersion {
+		committedSchema.SerializerVersion = serializers.SerializerVersion
+		err = schema.Write(committedSchema)
+		if err != nil {
+			return nil, err
+		}
+		result.Schema = schemaPath
+	}
+	return result, nil
+}
+
+// relabel sets the serializer_version of a migration file in place
+func relabel(fileBytes []byte) []byte {
+	line := []byte(fmt.Sprintf("serializer_version: %d", serializers.SerializerVersion))
+	if serializerVersionRegex.Match(fileBytes) {
+		return serializerVersionRegex.ReplaceAllLiteral(fileBytes, line)
+	}
+	return append(append(line, '\n'), fileBytes...)
+}
@@ -0,0 +1,83 @@
+package formatupgrades_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Betterment/testtrack-cli/formatupgrades"
+	"github.com/Betterment/testtrack-cli/migrationloaders"
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/stretchr/testify/require"
+)
+
+var v1Migration = `serializer_version: 1
+split:
+  name: "my_app.foo_experiment"
+  weights:
+    treatment: 50
+    control: 50
+`
+
+var v1Schema = `{
+  "serializer_version": 1,
+  "schema_version": "2020011774023",
+  "splits": [
+    {
+      "name": "my_app.foo_experiment",
+      "weights": {
+        "control": 50,
+        "treatment": 50
+      }
+    }
+  ]
+}`
+
+func TestUpgrade(t *testing.T) {
+	t.Chdir(t.TempDir())
+	migrationPath := filepath.Join("testtrack/migrate", "2020011774023_create_split_my_app.foo_experiment.yml")
+	require.NoError(t, os.MkdirAll("testtrack/migrate", 0755))
+	require.NoError(t, os.WriteFile(migrationPath, []byte(v1Migration), 0644))
+	require.NoError(t, os.WriteFile("testtrack/schema.json", []byte(v1Schema), 0644))
+
+	result, err := formatupgrades.Upgrade()
+	require.NoError(t, err)
+	require.Equal(t, []string{migrationPath}, result.Migrations)
+	require.Equal(t, "testtrack/schema.json", result.Schema)
+
+	migrationBytes, err := os.ReadFile(migrationPath)
+	require.NoError(t, err)
+	require.Equal(t, strings.Replace(v1Migration, "serializer_version: 1", "serializer_version: 2", 1), string(migrationBytes))
+
+	migrationRepo, err := migrationloaders.Load()
+	require.NoError(t, err)
+	file := migrationRepo["2020011774023"].File()
+	require.Equal(t, serializers.SerializerVersion, file.SerializerVersion)
+	require.Equal(t, "my_app.foo_experiment", file.Split.Name)
+
+	upgradedSchema, err := schema.ReadCommitted()
+	require.NoError(t, err)
+	require.Equal(t, serializers.SerializerVersion, upgradedSchema.SerializerVersion)
+	require.Equal(t, "2020011774023", upgradedSchema.SchemaVersion)
+	require.Len(t, upgradedSchema.Splits, 1)
+
+	t.Run("it leaves current files alone", func(t *testing.T) {
+		result, err := formatupgrades.Upgrade()
+		require.NoError(t, err)
+		require.Empty(t, result.Migrations)
+		require.Empty(t, result.Schema)
+	})
+
+	t.Run("it rejects files from newer versions", func(t *testing.T) {
+		future := filepath.Join("testtrack/migrate", "2020011774024_create_split_my_app.bar_experiment.yml")
+		require.NoError(t, os.WriteFile(future, []byte("serializer_version: 99\n"), 0644))
+
+		_, err := formatupgrades.Upgrade()
+		require.ErrorContains(t, err, "serializer_version 99")
+
+		_, err = migrationloaders.Load()
+		require.ErrorContains(t, err, "upgrade testtrack")
+	})
+}
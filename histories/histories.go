@@ -0,0 +1,117 @@
+package histories
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/migrations"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/splits"
+)
+
+// Actions a migration can take on a split
+const (
+	ActionCreate                   = "create"
+	ActionReweight                 = "reweight"
+	ActionDecide                   = "decide"
+	ActionRetire                   = "retire"
+	ActionRemoteKill               = "remote_kill"
+	ActionDestroyRemoteKill        = "destroy_remote_kill"
+	ActionFeatureCompletion        = "feature_completion"
+	ActionDestroyFeatureCompletion = "destroy_feature_completion"
+	ActionTransferOwnership        = "transfer_ownership"
+)
+
+// Entry describes a single migration in a split's history
+type Entry struct {
+	Version     string    `json:"version"`
+	Time        time.Time `json:"time"`
+	Kind        string    `json:"type"`
+	Action      string    `json:"action"`
+	Description string    `json:"description"`
+	Filename    string    `json:"filename"`
+	Author      string    `json:"author,omitempty"`
+}
+
+// ForSplit returns the migrations affecting a split, its owner, its remote
+// kills, or its feature completion, oldest first. A split migration is a
+// create if the split didn't exist or was retired at the time, and a reweight
+// otherwise.
+func ForSplit(name string, migrationRepo migrations.Repository) []Entry {
+	entries := []Entry{}
+	live := false
+	for _, version := range migrationRepo.SortedVersions() {
+		migration := migrationRepo[version]
+		file := migration.File()
+		action, description, ok := describe(name, file, live)
+		if !ok {
+			continue
+		}
+		switch action {
+		case ActionCreate, ActionReweight, ActionDecide:
+			live = true
+		case ActionRetire:
+			live = false
+		}
+		versionTime, _ := migrations.VersionTime(version) // Repository versions were validated on load
+		entries = append(entries, Entry{
+			Version:     version,
+			Time:        versionTime,
+			Kind:        file.Kind(),
+			Action:      action,
+			Description: description,
+			Filename:    *migration.Filename(),
+		})
+	}
+	return entries
+}
+
+// Author returns the git author who added a file, or an empty string if it
+// isn't committed or git isn't available
+func Author(path string) string {
+	out, err := exec.Command("git", "log", "--diff-filter=A", "--format=%an", "--", path).Output()
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	return lines[len(lines)-1]
+}
+
+func describe(name string, file *serializers.MigrationFile, live bool) (string, string, bool) {
+	switch {
+	case file.Split != nil && file.Split.Name == name:
+		weights := splits.Weights(file.Split.Weights)
+		description := "weights " + weights.String()
+		if file.Split.Owner != "" {
+			description += ", owned by " + file.Split.Owner
+		}
+		if live {
+			return ActionReweight, description, true
+		}
+		return ActionCreate, description, true
+	case file.SplitDecision != nil && file.SplitDecision.Split == name:
+		return ActionDecide, "decided " + file.SplitDecision.Variant, true
+	case file.SplitRetirement != nil && file.SplitRetirement.Split == name:
+		return ActionRetire, "retired with decision " + file.SplitRetirement.Decision, true
+	case file.RemoteKill != nil && file.RemoteKill.Split == name:
+		remoteKill := file.RemoteKill
+		if remoteKill.FirstBadVersion == nil {
+			return ActionDestroyRemoteKill, fmt.Sprintf("remote kill %s destroyed", remoteKill.Reason), true
+		}
+		description := fmt.Sprintf("remote kill %s overrides to %s from version %s", remoteKill.Reason, *remoteKill.OverrideTo, *remoteKill.FirstBadVersion)
+		if remoteKill.FixedVersion != nil && *remoteKill.FixedVersion != "" {
+			description += " until " + *remoteKill.FixedVersion
+		}
+		return ActionRemoteKill, description, true
+	case file.FeatureCompletion != nil && file.FeatureCompletion.FeatureGate == name:
+		if file.FeatureCompletion.Version == nil {
+			return ActionDestroyFeatureCompletion, "feature completion destroyed", true
+		}
+		return ActionFeatureCompletion, "feature completed in version " + *file.FeatureCompletion.Version, true
+	case file.OwnershipTransfer != nil && file.OwnershipTransfer.Split == name:
+		return ActionTransferOwnership, "ownership transferred to " + file.OwnershipTransfer.Owner, true
+	}
+	return "", "", false
+}
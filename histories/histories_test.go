@@ -0,0 +1,50 @@
+package histories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/histories"
+	"github.com/Betterment/testtrack-cli/internal/testutil"
+	"github.com/Betterment/testtrack-cli/splits"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForSplit(t *testing.T) {
+	name := "my_app.fancy_experiment"
+	otherName := "my_app.other_experiment"
+	weights := splits.Weights{"control": 50, "treatment": 50}
+	owner := "my_team"
+	reason := "crashes"
+	overrideTo := "control"
+	firstBadVersion := "1.0"
+	variant := "treatment"
+
+	fixtures := testutil.NewRepoBuilder(t)
+	fixtures.Split("2020010100001", name, owner, weights)
+	fixtures.Split("2020010100002", otherName, owner, weights)
+	fixtures.Split("2020010100003", name, owner, weights)
+	fixtures.RemoteKill("2020010100004", name, reason, &overrideTo, &firstBadVersion, nil)
+	fixtures.Decision("2020010100005", name, variant)
+	fixtures.Retirement("2020010100006", name, variant)
+	fixtures.Split("2020010186399", name, owner, weights)
+
+	entries := histories.ForSplit(name, fixtures.Repo)
+
+	actions := []string{}
+	for _, entry := range entries {
+		actions = append(actions, entry.Action)
+	}
+	require.Equal(t, []string{
+		histories.ActionCreate,
+		histories.ActionReweight,
+		histories.ActionRemoteKill,
+		histories.ActionDecide,
+		histories.ActionRetire,
+		histories.ActionCreate,
+	}, actions)
+
+	require.Equal(t, "weights control: 50, treatment: 50, owned by my_team", entries[0].Description)
+	require.Equal(t, "remote kill crashes overrides to control from version 1.0", entries[2].Description)
+	require.Equal(t, time.Date(2020, 1, 1, 23, 59, 59, 0, time.UTC), entries[5].Time)
+}
@@ -0,0 +1,82 @@
+// Package testutil holds helpers shared by tests. Only _test.go files may
+// import it, so its test dependencies stay out of the testtrack binary.
+package testutil
+
+import (
+	"testing"
+
+	"github.com/Betterment/testtrack-cli/featurecompletions"
+	"github.com/Betterment/testtrack-cli/migrations"
+	"github.com/Betterment/testtrack-cli/ownershiptransfers"
+	"github.com/Betterment/testtrack-cli/remotekills"
+	"github.com/Betterment/testtrack-cli/splitdecisions"
+	"github.com/Betterment/testtrack-cli/splitretirements"
+	"github.com/Betterment/testtrack-cli/splits"
+	"github.com/stretchr/testify/require"
+)
+
+// RepoBuilder builds a migration repository for tests, failing the test if a
+// migration can't be constructed
+type RepoBuilder struct {
+	t    testing.TB
+	Repo migrations.Repository
+}
+
+// NewRepoBuilder returns a RepoBuilder with an empty repository
+func NewRepoBuilder(t testing.TB) *RepoBuilder {
+	return &RepoBuilder{t: t, Repo: migrations.Repository{}}
+}
+
+// Add adds a migration to the repository at a version, overriding the version
+// it was generated with
+func (b *RepoBuilder) Add(version string, migration migrations.IMigration, err error) migrations.IMigration {
+	b.t.Helper()
+	require.NoError(b.t, err)
+	*migration.MigrationVersion() = version
+	b.Repo[version] = migration
+	return migration
+}
+
+// Split adds a split migration
+func (b *RepoBuilder) Split(version, name, owner string, weights splits.Weights) migrations.IMigration {
+	b.t.Helper()
+	migration, err := splits.New(&name, weights.Copy(), &owner, nil)
+	return b.Add(version, migration, err)
+}
+
+// Decision adds a split decision migration
+func (b *RepoBuilder) Decision(version, split, variant string) migrations.IMigration {
+	b.t.Helper()
+	migration, err := splitdecisions.New(&split, &variant)
+	return b.Add(version, migration, err)
+}
+
+// Retirement adds a split retirement migration
+func (b *RepoBuilder) Retirement(version, split, decision string) migrations.IMigration {
+	b.t.Helper()
+	migration, err := splitretirements.New(&split, &decision)
+	return b.Add(version, migration, err)
+}
+
+// RemoteKill adds a remote kill migration. A nil firstBadVersion destroys the
+// remote kill.
+func (b *RepoBuilder) RemoteKill(version, split, reason string, overrideTo, firstBadVersion, fixedVersion *string) migrations.IMigration {
+	b.t.Helper()
+	migration, err := remotekills.New(&split, &reason, overrideTo, firstBadVersion, fixedVersion, nil)
+	return b.Add(version, migration, err)
+}
+
+// FeatureCompletion adds a feature completion migration. A nil appVersion
+// destroys the feature completion.
+func (b *RepoBuilder) FeatureCompletion(version, featureGate string, appVersion *string) migrations.IMigration {
+	b.t.Helper()
+	migration, err := featurecompletions.New(&featureGate, appVersion, nil)
+	return b.Add(version, migration, err)
+}
+
+// OwnershipTransfer adds an ownership transfer migration
+func (b *RepoBuilder) OwnershipTransfer(version, split, owner string) migrations.IMigration {
+	b.t.Helper()
+	migration, err := ownershiptransfers.New(&split, &owner)
+	return b.Add(version, migration, err)
+}
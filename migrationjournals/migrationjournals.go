@@ -0,0 +1,144 @@
+package migrationjournals
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Steps a migration passes through while being applied to a TestTrack server
+const (
+	// StepSync means the migration's contents are being posted
+	StepSync = "sync"
+	// StepVersion means the contents were posted and the version is being recorded
+	StepVersion = "version"
+)
+
+const journalPath = "testtrack/migrate_journal.yml"
+
+const gitignorePath = "testtrack/.gitignore"
+
+// Entry records how far a migration got
+type Entry struct {
+	Step      string `yaml:"step"`
+	UpdatedAt string `yaml:"updated_at"`
+}
+
+// Journal records progress through each migration so an interrupted migrate
+// run can resume without re-posting migrations that already made it to the
+// server
+type Journal struct {
+	Entries map[string]Entry `yaml:"entries"`
+}
+
+// Load reads the journal from disk, returning an empty journal if there isn't one
+func Load() (*Journal, error) {
+	journal := &Journal{Entries: make(map[string]Entry)}
+
+	journalBytes, err := os.ReadFile(journalPath)
+	if os.IsNotExist(err) {
+		return journal, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = yaml.Unmarshal(journalBytes, journal)
+	if err != nil {
+		return nil, fmt.Errorf("%s is corrupt - delete it to migrate from scratch: %w", journalPath, err)
+	}
+	if journal.Entries == nil {
+		journal.Entries = make(map[string]Entry)
+	}
+	for version, entry := range journal.Entries {
+		if entry.Step != StepSync && entry.Step != StepVersion {
+			return nil, fmt.Errorf("%s is corrupt - delete it to migrate from scratch: migration %s has unknown step '%s'", journalPath, version, entry.Step)
+		}
+	}
+	return journal, nil
+}
+
+// StepFor returns the step a migration was on when it was last recorded
+func (j *Journal) StepFor(version string) (string, bool) {
+	entry, ok := j.Entries[version]
+	return entry.Step, ok
+}
+
+// Begin records that a migration is starting a step and persists the journal
+func (j *Journal) Begin(version, step string) error {
+	j.Entries[version] = Entry{
+		Step:      step,
+		UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	return j.save()
+}
+
+// Complete forgets a fully-applied migration and persists the journal
+func (j *Journal) Complete(version string) error {
+	delete(j.Entries, version)
+	return j.save()
+}
+
+// Retain forgets every migration not in the provided set of versions, e.g.
+// because the server already has them, and persists the journal
+func (j *Journal) Retain(versions []string) error {
+	retained := make(map[string]bool, len(versions))
+	for _, version := range versions {
+		retained[version] = true
+	}
+	changed := false
+	for version := range j.Entries {
+		if !retained[version] {
+			delete(j.Entries, version)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return j.save()
+}
+
+// save writes the journal to disk, removing the file once nothing is in flight
+func (j *Journal) save() error {
+	if len(j.Entries) == 0 {
+		err := os.Remove(journalPath)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	err := ensureIgnored()
+	if err != nil {
+		return err
+	}
+
+	out, err := yaml.Marshal(j)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(journalPath, out, 0644)
+}
+
+// ensureIgnored adds the journal to testtrack/.gitignore so it isn't
+// committed, for projects initialized before the journal existed
+func ensureIgnored() error {
+	gitignoreBytes, err := os.ReadFile(gitignorePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	gitignore := string(gitignoreBytes)
+	for _, line := range strings.Split(gitignore, "\n") {
+		if strings.TrimSpace(line) == "migrate_journal.yml" {
+			return nil
+		}
+	}
+	if gitignore != "" && !strings.HasSuffix(gitignore, "\n") {
+		gitignore += "\n"
+	}
+	return os.WriteFile(gitignorePath, []byte(gitignore+"migrate_journal.yml\n"), 0644)
+}
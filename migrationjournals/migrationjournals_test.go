@@ -0,0 +1,81 @@
+package migrationjournals_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Betterment/testtrack-cli/migrationjournals"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testtrack", 0755))
+
+	t.Run("it starts empty without a journal file", func(t *testing.T) {
+		journal, err := migrationjournals.Load()
+		require.NoError(t, err)
+		_, ok := journal.StepFor("2020010100001")
+		require.False(t, ok)
+	})
+
+	t.Run("it persists steps across loads", func(t *testing.T) {
+		journal, err := migrationjournals.Load()
+		require.NoError(t, err)
+		require.NoError(t, journal.Begin("2020010100001", migrationjournals.StepSync))
+		require.NoError(t, journal.Begin("2020010100002", migrationjournals.StepVersion))
+
+		journal, err = migrationjournals.Load()
+		require.NoError(t, err)
+		step, ok := journal.StepFor("2020010100001")
+		require.True(t, ok)
+		require.Equal(t, migrationjournals.StepSync, step)
+		step, _ = journal.StepFor("2020010100002")
+		require.Equal(t, migrationjournals.StepVersion, step)
+	})
+
+	t.Run("it forgets migrations the server already has", func(t *testing.T) {
+		journal, err := migrationjournals.Load()
+		require.NoError(t, err)
+		require.NoError(t, journal.Retain([]string{"2020010100002"}))
+
+		journal, err = migrationjournals.Load()
+		require.NoError(t, err)
+		_, ok := journal.StepFor("2020010100001")
+		require.False(t, ok)
+	})
+
+	t.Run("it removes the journal once nothing is in flight", func(t *testing.T) {
+		journal, err := migrationjournals.Load()
+		require.NoError(t, err)
+		require.NoError(t, journal.Complete("2020010100002"))
+
+		_, err = os.Stat("testtrack/migrate_journal.yml")
+		require.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("it rejects a corrupt journal", func(t *testing.T) {
+		require.NoError(t, os.WriteFile("testtrack/migrate_journal.yml", []byte("entries: [oops"), 0644))
+		_, err := migrationjournals.Load()
+		require.ErrorContains(t, err, "testtrack/migrate_journal.yml is corrupt - delete it to migrate from scratch")
+
+		require.NoError(t, os.WriteFile("testtrack/migrate_journal.yml", []byte("entries:\n  \"2020010100001\":\n    step: posted\n"), 0644))
+		_, err = migrationjournals.Load()
+		require.EqualError(t, err, "testtrack/migrate_journal.yml is corrupt - delete it to migrate from scratch: migration 2020010100001 has unknown step 'posted'")
+	})
+}
+
+func TestJournalGitignore(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testtrack", 0755))
+	require.NoError(t, os.WriteFile("testtrack/.gitignore", []byte("build_timestamp"), 0644))
+
+	journal, err := migrationjournals.Load()
+	require.NoError(t, err)
+	require.NoError(t, journal.Begin("2020010100001", migrationjournals.StepSync))
+	require.NoError(t, journal.Begin("2020010100001", migrationjournals.StepVersion))
+
+	gitignore, err := os.ReadFile("testtrack/.gitignore")
+	require.NoError(t, err)
+	require.Equal(t, "build_timestamp\nmigrate_journal.yml\n", string(gitignore))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/Betterment/testtrack-cli/featurecompletions"
 	"github.com/Betterment/testtrack-cli/identifiertypes"
 	"github.com/Betterment/testtrack-cli/migrations"
+	"github.com/Betterment/testtrack-cli/ownershiptransfers"
 	"github.com/Betterment/testtrack-cli/remotekills"
 	"github.com/Betterment/testtrack-cli/serializers"
 	"github.com/Betterment/testtrack-cli/splitdecisions"
@@ -46,6 +47,11 @@ func Load() (migrations.Repository, error) {
 			return nil, err
 		}
 
+		err = serializers.CheckSerializerVersion("testtrack/migrate/"+file.Name(), migrationFile.SerializerVersion)
+		if err != nil {
+			return nil, err
+		}
+
 		if migrationFile.FeatureCompletion != nil {
 			migrationRepo[migrationVersion] = featurecompletions.FromFile(&migrationVersion, migrationFile.FeatureCompletion)
 		} else if migrationFile.RemoteKill != nil {
@@ -61,9 +67,35 @@ func Load() (migrations.Repository, error) {
 			migrationRepo[migrationVersion] = splitdecisions.FromFile(&migrationVersion, migrationFile.SplitDecision)
 		} else if migrationFile.IdentifierType != nil {
 			migrationRepo[migrationVersion] = identifiertypes.FromFile(&migrationVersion, migrationFile.IdentifierType)
+		} else if migrationFile.OwnershipTransfer != nil {
+			migrationRepo[migrationVersion] = ownershiptransfers.FromFile(&migrationVersion, migrationFile.OwnershipTransfer)
 		} else {
 			return nil, fmt.Errorf("testtrack/migrate/%s didn't match a known migration type", file.Name())
 		}
 	}
 	return migrationRepo, nil
 }
+
+// Paths returns the path of each migration file in the current project's
+// testtrack/migrate by version, as named on disk
+func Paths() (map[string]string, error) {
+	dir := "testtrack/migrate"
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]string, len(files))
+	for _, file := range files {
+		if strings.HasPrefix(file.Name(), ".") {
+			continue // Skip hidden files
+		}
+
+		migrationVersion, err := migrations.ExtractVersionFromFilename(file.Name())
+		if err != nil {
+			return nil, err
+		}
+		paths[migrationVersion] = path.Join(dir, file.Name())
+	}
+	return paths, nil
+}
@@ -100,13 +100,18 @@ func (m *MigrationManager) ApplyToSchema(migrationRepo migrations.Repository, id
 	return nil
 }
 
-// Sync applies the contents of a migration to the TestTrack server
+// Sync applies the contents of a migration to the TestTrack server. Migrations
+// with no sync path only affect the local schema, so they're not posted.
 func (m *MigrationManager) Sync() error {
 	err := m.migration.Validate()
 	if err != nil {
 		return err
 	}
 
+	if m.migration.SyncPath() == "" {
+		return nil
+	}
+
 	resp, err := m.server.Post(m.migration.SyncPath(), m.migration.Serializable())
 	if err != nil {
 		return err
@@ -1,12 +1,17 @@
 package migrationrunners
 
 import (
+	"fmt"
+	"os"
+
+	"github.com/Betterment/testtrack-cli/migrationjournals"
 	"github.com/Betterment/testtrack-cli/migrationloaders"
 	"github.com/Betterment/testtrack-cli/migrationmanagers"
 	"github.com/Betterment/testtrack-cli/migrations"
 	"github.com/Betterment/testtrack-cli/schema"
 	"github.com/Betterment/testtrack-cli/serializers"
 	"github.com/Betterment/testtrack-cli/servers"
+	"github.com/Betterment/testtrack-cli/splits"
 )
 
 // Runner runs sets of migrations
@@ -25,41 +30,188 @@ func New(server servers.IServer) (*Runner, error) {
 	return &Runner{server: server, schema: schema}, nil
 }
 
-// RunOutstanding runs all outstanding migrations
-func (r *Runner) RunOutstanding() error {
-	migrationRepo, err := r.getOutstandingMigrations()
+// PlannedMigration describes an outstanding migration without applying it
+type PlannedMigration struct {
+	Version  string      `json:"version"`
+	Kind     string      `json:"type"`
+	Resource string      `json:"resource"`
+	SyncPath string      `json:"sync_path"`
+	Payload  interface{} `json:"payload"`
+	// VersionOnly means an interrupted run already synced the migration, so
+	// only its version would be recorded
+	VersionOnly bool `json:"version_only,omitempty"`
+}
+
+// PlanOutstanding validates and describes all outstanding migrations in the
+// order they would run, without applying them
+func (r *Runner) PlanOutstanding() ([]PlannedMigration, error) {
+	migrationRepo, _, err := r.getOutstandingMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	journal, err := migrationjournals.Load()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	versions := migrationRepo.SortedVersions()
 
+	plan := make([]PlannedMigration, 0, len(versions))
 	for _, version := range versions {
-		mgr := migrationmanagers.NewWithServer(migrationRepo[version], r.server)
-		err := mgr.Migrate()
+		migration := migrationRepo[version]
+		err := migration.Validate()
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("migration %s is invalid: %w", version, err)
 		}
+		file := migration.File()
+		step, _ := journal.StepFor(version)
+		plan = append(plan, PlannedMigration{
+			Version:     version,
+			Kind:        file.Kind(),
+			Resource:    file.Resource(),
+			SyncPath:    migration.SyncPath(),
+			Payload:     migration.Serializable(),
+			VersionOnly: step == migrationjournals.StepVersion,
+		})
 	}
 
-	return nil
+	return plan, nil
 }
 
-func (r *Runner) getOutstandingMigrations() (migrations.Repository, error) {
-	migrationRepo, err := migrationloaders.Load()
+// RunOutstanding runs all outstanding migrations, journaling progress so that
+// an interrupted run can resume without re-posting migrations the server
+// already accepted. Outstanding versions come from the server, so a journaled
+// migration is only resumed while the server still lacks its version. It
+// returns the versions applied, in order, including those applied before a
+// failure.
+func (r *Runner) RunOutstanding() ([]string, error) {
+	journal, err := migrationjournals.Load()
 	if err != nil {
 		return nil, err
 	}
 
-	appliedMigrationVersions, err := r.getAppliedMigrationVersions()
+	migrationRepo, allMigrations, err := r.getOutstandingMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := migrationRepo.SortedVersions()
+	applied := make([]string, 0, len(versions))
+
+	// Anything journaled that's no longer outstanding was versioned on the server
+	err = journal.Retain(versions)
 	if err != nil {
 		return nil, err
 	}
 
+	for _, version := range versions {
+		mgr := migrationmanagers.NewWithServer(migrationRepo[version], r.server)
+
+		step, _ := journal.StepFor(version)
+		if step == migrationjournals.StepSync {
+			landed, err := r.syncLanded(migrationRepo[version], version, allMigrations)
+			if err != nil {
+				return applied, fmt.Errorf("migration %s failed at %s step: %w", version, migrationjournals.StepSync, err)
+			}
+			if landed {
+				step = migrationjournals.StepVersion
+			} else {
+				fmt.Fprintf(os.Stderr, "Resuming migration %s: sync was interrupted and can't be confirmed on the server, posting again\n", version)
+			}
+		}
+
+		if step == migrationjournals.StepVersion {
+			fmt.Fprintf(os.Stderr, "Resuming migration %s: already synced, recording version only\n", version)
+		} else {
+			err = journal.Begin(version, migrationjournals.StepSync)
+			if err != nil {
+				return applied, err
+			}
+			err = mgr.Sync()
+			if err != nil {
+				return applied, fmt.Errorf("migration %s failed at %s step: %w", version, migrationjournals.StepSync, err)
+			}
+		}
+
+		err = journal.Begin(version, migrationjournals.StepVersion)
+		if err != nil {
+			return applied, err
+		}
+		err = mgr.SyncVersion()
+		if err != nil {
+			return applied, fmt.Errorf("migration %s failed at %s step: %w", version, migrationjournals.StepVersion, err)
+		}
+
+		err = journal.Complete(version)
+		if err != nil {
+			return applied, err
+		}
+		applied = append(applied, version)
+	}
+
+	return applied, nil
+}
+
+// syncLanded checks whether an interrupted sync of a split or split decision
+// reached the server by comparing the server's split registry with the weights
+// the migration leaves the split with. Other migrations can't be confirmed, so
+// they're reported as not landed and posted again, which TestTrack's upserting
+// migration endpoints tolerate.
+func (r *Runner) syncLanded(migration migrations.IMigration, version string, allMigrations migrations.Repository) (bool, error) {
+	file := migration.File()
+	if file.Split == nil && file.SplitDecision == nil {
+		return false, nil
+	}
+
+	expected, err := schema.Replay(allMigrations, version)
+	if err != nil {
+		return false, err
+	}
+	err = migration.ApplyToSchema(expected, allMigrations, false)
+	if err != nil {
+		return false, err
+	}
+
+	var registry serializers.RemoteRegistry
+	err = r.server.Get("api/v2/split_registry.json", &registry)
+	if err != nil {
+		return false, err
+	}
+	remoteSplit, ok := registry.Splits[file.Resource()]
+	if !ok {
+		return false, nil
+	}
+	for _, split := range expected.Splits {
+		if split.Name == file.Resource() {
+			weights := splits.Weights(split.Weights)
+			return weights.Equal(remoteSplit.Weights), nil
+		}
+	}
+	return false, nil
+}
+
+// getOutstandingMigrations returns the migrations not yet applied to the
+// server, along with every migration in the project
+func (r *Runner) getOutstandingMigrations() (migrations.Repository, migrations.Repository, error) {
+	allMigrations, err := migrationloaders.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	appliedMigrationVersions, err := r.getAppliedMigrationVersions()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	migrationRepo := make(migrations.Repository, len(allMigrations))
+	for version, migration := range allMigrations {
+		migrationRepo[version] = migration
+	}
 	for _, version := range appliedMigrationVersions {
 		delete(migrationRepo, version.Version)
 	}
-	return migrationRepo, nil
+	return migrationRepo, allMigrations, nil
 }
 
 func (r *Runner) getAppliedMigrationVersions() ([]serializers.MigrationVersion, error) {
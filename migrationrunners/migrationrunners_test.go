@@ -0,0 +1,168 @@
+package migrationrunners_test
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Betterment/testtrack-cli/migrationrunners"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/stretchr/testify/require"
+)
+
+var splitMigration = `serializer_version: 1
+split:
+  name: my_app.foo_experiment
+  weights:
+    control: 50
+    treatment: 50
+`
+
+var decisionMigration = `serializer_version: 1
+split_decision:
+  split: my_app.foo_experiment
+  variant: treatment
+`
+
+var transferMigration = `serializer_version: 2
+ownership_transfer:
+  split: my_app.foo_experiment
+  owner: checkout
+`
+
+type fakeServer struct {
+	posts        []string
+	failVersions bool
+	failPath     string
+	registry     serializers.RemoteRegistry
+}
+
+func (f *fakeServer) Get(path string, v interface{}) error {
+	switch v := v.(type) {
+	case *[]serializers.MigrationVersion:
+		*v = []serializers.MigrationVersion{}
+	case *serializers.RemoteRegistry:
+		*v = f.registry
+	}
+	return nil
+}
+
+func (f *fakeServer) Post(path string, body interface{}) (*http.Response, error) {
+	if path == "api/v2/migrations" && f.failVersions {
+		return nil, errors.New("connection reset")
+	}
+	if path == f.failPath {
+		return &http.Response{StatusCode: http.StatusUnprocessableEntity}, nil
+	}
+	f.posts = append(f.posts, path)
+	return &http.Response{StatusCode: http.StatusNoContent}, nil
+}
+
+func (f *fakeServer) Delete(path string) error {
+	return nil
+}
+
+func TestRunOutstanding(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testtrack/migrate", 0755))
+	require.NoError(t, os.WriteFile(filepath.Join("testtrack/migrate", "2020011774023_create_split_my_app.foo_experiment.yml"), []byte(splitMigration), 0644))
+
+	server := &fakeServer{failVersions: true}
+	runner, err := migrationrunners.New(server)
+	require.NoError(t, err)
+
+	t.Run("it reports the step that failed and journals progress", func(t *testing.T) {
+		_, err := runner.RunOutstanding()
+		require.EqualError(t, err, "migration 2020011774023 failed at version step: connection reset")
+		require.Equal(t, []string{"api/v2/migrations/split"}, server.posts)
+
+		plan, err := runner.PlanOutstanding()
+		require.NoError(t, err)
+		require.True(t, plan[0].VersionOnly)
+	})
+
+	t.Run("it resumes without re-posting the migration", func(t *testing.T) {
+		server.failVersions = false
+
+		applied, err := runner.RunOutstanding()
+		require.NoError(t, err)
+		require.Equal(t, []string{"2020011774023"}, applied)
+		require.Equal(t, []string{"api/v2/migrations/split", "api/v2/migrations"}, server.posts)
+
+		_, err = os.Stat("testtrack/migrate_journal.yml")
+		require.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestRunOutstandingPartialFailure(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testtrack/migrate", 0755))
+	require.NoError(t, os.WriteFile(filepath.Join("testtrack/migrate", "2020011774023_create_split_my_app.foo_experiment.yml"), []byte(splitMigration), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join("testtrack/migrate", "2020011774024_create_split_decision_my_app.foo_experiment.yml"), []byte(decisionMigration), 0644))
+
+	server := &fakeServer{failPath: "api/v2/migrations/split_decision"}
+	runner, err := migrationrunners.New(server)
+	require.NoError(t, err)
+
+	applied, err := runner.RunOutstanding()
+	require.EqualError(t, err, "migration 2020011774024 failed at sync step: migration unsuccessful on server. Does your split exist?")
+	require.Equal(t, []string{"2020011774023"}, applied)
+}
+
+func TestRunOutstandingOwnershipTransfer(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testtrack/migrate", 0755))
+	require.NoError(t, os.WriteFile(filepath.Join("testtrack/migrate", "2020011774023_create_split_my_app.foo_experiment.yml"), []byte(splitMigration), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join("testtrack/migrate", "2020011774024_create_ownership_transfer_my_app.foo_experiment.yml"), []byte(transferMigration), 0644))
+
+	server := &fakeServer{}
+	runner, err := migrationrunners.New(server)
+	require.NoError(t, err)
+
+	_, err = runner.RunOutstanding()
+	require.NoError(t, err)
+	require.Equal(t, []string{"api/v2/migrations/split", "api/v2/migrations", "api/v2/migrations"}, server.posts)
+}
+
+func TestRunOutstandingResumesInterruptedSync(t *testing.T) {
+	journal := `entries:
+  "2020011774023":
+    step: sync
+    updated_at: "2020-01-17T20:33:43Z"
+`
+
+	setup := func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		require.NoError(t, os.MkdirAll("testtrack/migrate", 0755))
+		require.NoError(t, os.WriteFile(filepath.Join("testtrack/migrate", "2020011774023_create_split_my_app.foo_experiment.yml"), []byte(splitMigration), 0644))
+		require.NoError(t, os.WriteFile("testtrack/migrate_journal.yml", []byte(journal), 0644))
+	}
+
+	t.Run("it records only the version when the server reflects the sync", func(t *testing.T) {
+		setup(t)
+		server := &fakeServer{registry: serializers.RemoteRegistry{Splits: map[string]serializers.RemoteRegistrySplit{
+			"my_app.foo_experiment": {Weights: map[string]int{"control": 50, "treatment": 50}},
+		}}}
+		runner, err := migrationrunners.New(server)
+		require.NoError(t, err)
+
+		applied, err := runner.RunOutstanding()
+		require.NoError(t, err)
+		require.Equal(t, []string{"2020011774023"}, applied)
+		require.Equal(t, []string{"api/v2/migrations"}, server.posts)
+	})
+
+	t.Run("it posts again when the server doesn't reflect the sync", func(t *testing.T) {
+		setup(t)
+		server := &fakeServer{}
+		runner, err := migrationrunners.New(server)
+		require.NoError(t, err)
+
+		applied, err := runner.RunOutstanding()
+		require.NoError(t, err)
+		require.Equal(t, []string{"2020011774023"}, applied)
+		require.Equal(t, []string{"api/v2/migrations/split", "api/v2/migrations"}, server.posts)
+	})
+}
@@ -69,6 +69,23 @@ func GenerateMigrationVersion() (*string, error) {
 	return &longVersion, nil
 }
 
+// VersionTime decodes the UTC time a migration version was generated, ignoring
+// any vNNN suffix
+func VersionTime(version string) (time.Time, error) {
+	if len(version) < 13 {
+		return time.Time{}, fmt.Errorf("can't parse time of migration version %s", version)
+	}
+	day, err := time.Parse("20060102", version[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("can't parse date of migration version %s: %w", version, err)
+	}
+	secondsIntoDay, err := strconv.Atoi(version[8:13])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("can't parse seconds of migration version %s: %w", version, err)
+	}
+	return day.Add(time.Duration(secondsIntoDay) * time.Second), nil
+}
+
 // ExtractVersionFromFilename returns the migration version from a filename
 func ExtractVersionFromFilename(filename string) (string, error) {
 	matches := migrationFilenameRegex.FindStringSubmatch(filename)
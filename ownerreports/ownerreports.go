@@ -0,0 +1,84 @@
+package ownerreports
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/histories"
+	"github.com/Betterment/testtrack-cli/migrations"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/splits"
+)
+
+// Unowned is the owner splits without an owner are reported under
+const Unowned = "(unowned)"
+
+// OwnedSplit describes a split in an owner's report
+type OwnedSplit struct {
+	Name string `json:"name"`
+	// Days since the split was last created, or nil if it predates migrations
+	Days     *int   `json:"days"`
+	Decided  bool   `json:"decided"`
+	Decision string `json:"decision,omitempty"`
+}
+
+// Owner lists the splits an owner is responsible for
+type Owner struct {
+	Owner  string       `json:"owner"`
+	Splits []OwnedSplit `json:"splits"`
+}
+
+// Build groups a schema's splits by owner, sorted by owner and split name.
+// Owners from owners.yml that own no splits are included so that they can be
+// cleaned up, and splits without an owner are reported under Unowned, last.
+func Build(schema *serializers.Schema, migrationRepo migrations.Repository, owners []string, now time.Time) []Owner {
+	byOwner := make(map[string][]OwnedSplit)
+	for _, owner := range owners {
+		byOwner[owner] = []OwnedSplit{}
+	}
+
+	for _, split := range schema.Splits {
+		owner := split.Owner
+		if owner == "" {
+			owner = Unowned
+		}
+		ownedSplit := OwnedSplit{
+			Name:    split.Name,
+			Days:    age(split.Name, migrationRepo, now),
+			Decided: split.Decided,
+		}
+		if split.Decided {
+			weights := splits.Weights(split.Weights)
+			ownedSplit.Decision, _ = weights.DecidedVariant()
+		}
+		byOwner[owner] = append(byOwner[owner], ownedSplit)
+	}
+
+	report := make([]Owner, 0, len(byOwner))
+	for owner, ownedSplits := range byOwner {
+		sort.Slice(ownedSplits, func(i, j int) bool {
+			return ownedSplits[i].Name < ownedSplits[j].Name
+		})
+		report = append(report, Owner{Owner: owner, Splits: ownedSplits})
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Owner == Unowned || report[j].Owner == Unowned {
+			return report[j].Owner == Unowned && report[i].Owner != Unowned
+		}
+		return report[i].Owner < report[j].Owner
+	})
+	return report
+}
+
+// age returns the days since the split was last created, which is reset when
+// a retired split is revived
+func age(name string, migrationRepo migrations.Repository, now time.Time) *int {
+	entries := histories.ForSplit(name, migrationRepo)
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Action == histories.ActionCreate {
+			days := int(now.Sub(entries[i].Time).Hours() / 24)
+			return &days
+		}
+	}
+	return nil
+}
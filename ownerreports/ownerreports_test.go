@@ -0,0 +1,57 @@
+package ownerreports_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/internal/testutil"
+	"github.com/Betterment/testtrack-cli/ownerreports"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/splits"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild(t *testing.T) {
+	fixtures := testutil.NewRepoBuilder(t)
+	weights := splits.Weights{"control": 50, "treatment": 50}
+	fixtures.Split("2020010100000", "my_app.transferred_experiment", "growth", weights)
+	fixtures.OwnershipTransfer("2020020100000", "my_app.transferred_experiment", "checkout")
+
+	fixtures.Split("2020010100001", "my_app.revived_experiment", "growth", weights)
+	fixtures.Retirement("2020020100001", "my_app.revived_experiment", "treatment")
+	fixtures.Split("2020050100000", "my_app.revived_experiment", "", weights)
+
+	fixtures.Split("2020010100002", "my_app.decided_experiment", "growth", weights)
+	fixtures.Decision("2020020100002", "my_app.decided_experiment", "treatment")
+
+	fixtures.Split("2020010100003", "my_app.orphan_experiment", "", weights)
+	repo := fixtures.Repo
+
+	schema := &serializers.Schema{
+		Splits: []serializers.SchemaSplit{
+			{Name: "legacy_experiment", Weights: map[string]int{"control": 50, "treatment": 50}, Owner: "growth"},
+		},
+	}
+	for _, version := range repo.SortedVersions() {
+		require.NoError(t, repo[version].ApplyToSchema(schema, repo, false))
+	}
+
+	now := time.Date(2020, 6, 30, 0, 0, 0, 0, time.UTC)
+	report := ownerreports.Build(schema, repo, []string{"checkout", "growth", "platform"}, now)
+
+	days := func(d int) *int { return &d }
+	require.Equal(t, []ownerreports.Owner{
+		{Owner: "checkout", Splits: []ownerreports.OwnedSplit{
+			{Name: "my_app.transferred_experiment", Days: days(181)},
+		}},
+		{Owner: "growth", Splits: []ownerreports.OwnedSplit{
+			{Name: "legacy_experiment"},
+			{Name: "my_app.decided_experiment", Days: days(180), Decided: true, Decision: "treatment"},
+			{Name: "my_app.revived_experiment", Days: days(60)},
+		}},
+		{Owner: "platform", Splits: []ownerreports.OwnedSplit{}},
+		{Owner: ownerreports.Unowned, Splits: []ownerreports.OwnedSplit{
+			{Name: "my_app.orphan_experiment", Days: days(180)},
+		}},
+	}, report)
+}
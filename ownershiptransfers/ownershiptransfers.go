@@ -0,0 +1,112 @@
+package ownershiptransfers
+
+import (
+	"fmt"
+
+	"github.com/Betterment/testtrack-cli/migrations"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/splits"
+	"github.com/Betterment/testtrack-cli/validations"
+)
+
+// OwnershipTransfer represents a split changing owners
+type OwnershipTransfer struct {
+	migrationVersion *string
+	split            *string
+	owner            *string
+}
+
+// New returns a migration object
+func New(split, owner *string) (migrations.IMigration, error) {
+	migrationVersion, err := migrations.GenerateMigrationVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	return &OwnershipTransfer{
+		migrationVersion: migrationVersion,
+		split:            split,
+		owner:            owner,
+	}, nil
+}
+
+// FromFile reifies a migration from the yaml serializable representation
+func FromFile(migrationVersion *string, serializable *serializers.OwnershipTransfer) migrations.IMigration {
+	return &OwnershipTransfer{
+		migrationVersion: migrationVersion,
+		split:            &serializable.Split,
+		owner:            &serializable.Owner,
+	}
+}
+
+// Validate validates that an ownership transfer may be persisted
+func (o *OwnershipTransfer) Validate() error {
+	err := validations.Split("split", o.split)
+	if err != nil {
+		return err
+	}
+	return validations.Presence("owner", o.owner)
+}
+
+// Filename generates a filename for this migration
+func (o *OwnershipTransfer) Filename() *string {
+	filename := fmt.Sprintf("%s_create_ownership_transfer_%s.yml", *o.migrationVersion, *o.split)
+	return &filename
+}
+
+// File returns a serializable MigrationFile for this migration
+func (o *OwnershipTransfer) File() *serializers.MigrationFile {
+	return &serializers.MigrationFile{
+		SerializerVersion: serializers.SerializerVersion,
+		OwnershipTransfer: &serializers.OwnershipTransfer{
+			Split: *o.split,
+			Owner: *o.owner,
+		},
+	}
+}
+
+// SyncPath returns an empty path because the TestTrack server doesn't track
+// ownership changes, so ownership transfers only affect the local schema
+func (o *OwnershipTransfer) SyncPath() string {
+	return ""
+}
+
+// Serializable returns a JSON-serializable representation
+func (o *OwnershipTransfer) Serializable() interface{} {
+	return &serializers.OwnershipTransfer{
+		Split: *o.split,
+		Owner: *o.owner,
+	}
+}
+
+// MigrationVersion returns the migration version
+func (o *OwnershipTransfer) MigrationVersion() *string {
+	return o.migrationVersion
+}
+
+// ResourceKey returns the natural key of the resource under migration
+func (o *OwnershipTransfer) ResourceKey() splits.SplitKey {
+	return splits.SplitKey(*o.split)
+}
+
+// SameResourceAs returns whether the migrations refer to the same TestTrack resource
+func (o *OwnershipTransfer) SameResourceAs(other migrations.IMigration) bool {
+	if otherS, ok := other.(splits.ISplitMigration); ok {
+		return otherS.ResourceKey() == o.ResourceKey()
+	}
+	return false
+}
+
+// ApplyToSchema applies a migrations changes to in-memory schema representation
+func (o *OwnershipTransfer) ApplyToSchema(schema *serializers.Schema, _ migrations.Repository, idempotently bool) error {
+	for i, candidate := range schema.Splits {
+		if candidate.Name == *o.split {
+			schema.Splits[i].Owner = *o.owner
+			return nil
+		}
+	}
+	if idempotently {
+		return nil
+	}
+	return fmt.Errorf("couldn't locate split %s in schema to transfer ownership", *o.split)
+}
@@ -0,0 +1,44 @@
+package ownershiptransfers_test
+
+import (
+	"testing"
+
+	"github.com/Betterment/testtrack-cli/ownershiptransfers"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwnershipTransfer(t *testing.T) {
+	t.Chdir(t.TempDir())
+	split, owner := "my_app.fancy_experiment", "checkout"
+	transfer, err := ownershiptransfers.New(&split, &owner)
+	require.NoError(t, err)
+
+	t.Run("it requires an owner", func(t *testing.T) {
+		blank := ""
+		invalid, err := ownershiptransfers.New(&split, &blank)
+		require.NoError(t, err)
+		require.Error(t, invalid.Validate())
+		require.NoError(t, transfer.Validate())
+	})
+
+	t.Run("it changes the owner of the split in the schema", func(t *testing.T) {
+		schema := &serializers.Schema{
+			Splits: []serializers.SchemaSplit{
+				{Name: split, Weights: map[string]int{"control": 50, "treatment": 50}, Owner: "growth"},
+			},
+		}
+		require.NoError(t, transfer.ApplyToSchema(schema, nil, false))
+		require.Equal(t, "checkout", schema.Splits[0].Owner)
+	})
+
+	t.Run("it fails to transfer a missing split unless applied idempotently", func(t *testing.T) {
+		schema := &serializers.Schema{}
+		require.EqualError(t, transfer.ApplyToSchema(schema, nil, false), "couldn't locate split my_app.fancy_experiment in schema to transfer ownership")
+		require.NoError(t, transfer.ApplyToSchema(schema, nil, true))
+	})
+
+	t.Run("it only changes the local schema", func(t *testing.T) {
+		require.Equal(t, "", transfer.SyncPath())
+	})
+}
@@ -0,0 +1,330 @@
+package recordings
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Exchange is the JSON-marshalable record of a request a server handled and
+// its response
+type Exchange struct {
+	Time     time.Time `json:"time"`
+	Request  Request   `json:"request"`
+	Response Response  `json:"response"`
+}
+
+// Request is the JSON-marshalable record of an HTTP request
+type Request struct {
+	Method      string `json:"method"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type,omitempty"`
+	Accept      string `json:"accept,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+// Response is the JSON-marshalable record of an HTTP response
+type Response struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+// Recorder writes every exchange handled by a wrapped handler to a JSON lines
+// log
+type Recorder struct {
+	mutex sync.Mutex
+	w     io.Writer
+}
+
+// NewRecorder returns a Recorder writing to w
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w}
+}
+
+// Handler wraps next, recording each request it handles and its response
+func (rec *Recorder) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestBody, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(requestBody))
+
+		rw := &recordingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rw, r)
+
+		rec.write(Exchange{
+			Time: time.Now().UTC(),
+			Request: Request{
+				Method:      r.Method,
+				URL:         r.URL.RequestURI(),
+				ContentType: r.Header.Get("Content-Type"),
+				Accept:      r.Header.Get("Accept"),
+				Body:        string(requestBody),
+			},
+			Response: Response{
+				Status:      rw.status,
+				ContentType: rw.Header().Get("Content-Type"),
+				Body:        rw.body.String(),
+			},
+		})
+	})
+}
+
+func (rec *Recorder) write(exchange Exchange) {
+	bytes, err := json.Marshal(exchange)
+	if err != nil {
+		return
+	}
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+	// A failed write shouldn't fail the request being recorded
+	rec.w.Write(append(bytes, '\n'))
+}
+
+type recordingResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rw *recordingResponseWriter) WriteHeader(status int) {
+	rw.status = status
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *recordingResponseWriter) Write(b []byte) (int, error) {
+	rw.body.Write(b)
+	return rw.ResponseWriter.Write(b)
+}
+
+// ReadFile reads the exchanges in a recording
+func ReadFile(path string) ([]Exchange, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	exchanges := []Exchange{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var exchange Exchange
+		err := json.Unmarshal(scanner.Bytes(), &exchange)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %s line %d: %w", path, line, err)
+		}
+		exchanges = append(exchanges, exchange)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return exchanges, nil
+}
+
+// Doer sends HTTP requests, like an *http.Client or an authenticated
+// *servers.Server
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Replay re-issues a recorded request against the server at baseURL with the
+// client and returns its response
+func Replay(client Doer, baseURL *url.URL, request Request) (*Response, error) {
+	target, err := url.Parse(strings.TrimSuffix(baseURL.String(), "/") + request.URL)
+	if err != nil {
+		return nil, err
+	}
+	var body io.Reader
+	if request.Body != "" {
+		body = strings.NewReader(request.Body)
+	}
+	req, err := http.NewRequest(request.Method, target.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if request.ContentType != "" {
+		req.Header.Set("Content-Type", request.ContentType)
+	}
+	if request.Accept != "" {
+		req.Header.Set("Accept", request.Accept)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &Response{
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        string(responseBody),
+	}, nil
+}
+
+// DiffOptions configures how responses are compared
+type DiffOptions struct {
+	// IgnoreFields are JSON object keys left out of the comparison at any
+	// depth, e.g. ids and timestamps that differ between servers
+	IgnoreFields []string
+	// IgnoreOrder compares JSON arrays without regard to element order
+	IgnoreOrder bool
+}
+
+// Diff describes the differences between a recorded response and a replayed
+// one. JSON bodies are compared structurally, and other bodies byte for byte.
+func Diff(expected, actual *Response, options DiffOptions) []string {
+	differences := []string{}
+	if expected.Status != actual.Status {
+		differences = append(differences, fmt.Sprintf("status: expected %d, got %d", expected.Status, actual.Status))
+	}
+
+	var expectedJSON, actualJSON interface{}
+	expectedErr := json.Unmarshal([]byte(expected.Body), &expectedJSON)
+	actualErr := json.Unmarshal([]byte(actual.Body), &actualJSON)
+	if expectedErr != nil || actualErr != nil {
+		if expected.Body != actual.Body {
+			differences = append(differences, fmt.Sprintf("body: expected %q, got %q", expected.Body, actual.Body))
+		}
+		return differences
+	}
+
+	ignored := make(map[string]bool, len(options.IgnoreFields))
+	for _, field := range options.IgnoreFields {
+		ignored[field] = true
+	}
+	return diffValues("$", expectedJSON, actualJSON, ignored, options.IgnoreOrder, differences)
+}
+
+func diffValues(path string, expected, actual interface{}, ignored map[string]bool, ignoreOrder bool, differences []string) []string {
+	switch expected := expected.(type) {
+	case map[string]interface{}:
+		actual, ok := actual.(map[string]interface{})
+		if !ok {
+			return append(differences, fmt.Sprintf("%s: expected %s, got %s", path, describe(expected), describe(actual)))
+		}
+		for _, key := range sortedKeys(expected, actual) {
+			if ignored[key] {
+				continue
+			}
+			expectedValue, inExpected := expected[key]
+			actualValue, inActual := actual[key]
+			switch {
+			case !inActual:
+				differences = append(differences, fmt.Sprintf("%s.%s: missing", path, key))
+			case !inExpected:
+				differences = append(differences, fmt.Sprintf("%s.%s: unexpected %s", path, key, describe(actualValue)))
+			default:
+				differences = diffValues(path+"."+key, expectedValue, actualValue, ignored, ignoreOrder, differences)
+			}
+		}
+		return differences
+	case []interface{}:
+		actual, ok := actual.([]interface{})
+		if !ok {
+			return append(differences, fmt.Sprintf("%s: expected %s, got %s", path, describe(expected), describe(actual)))
+		}
+		if len(expected) != len(actual) {
+			return append(differences, fmt.Sprintf("%s: expected %d elements, got %d", path, len(expected), len(actual)))
+		}
+		if ignoreOrder {
+			expected = sortedByEncoding(expected, ignored)
+			actual = sortedByEncoding(actual, ignored)
+		}
+		for i := range expected {
+			differences = diffValues(fmt.Sprintf("%s[%d]", path, i), expected[i], actual[i], ignored, ignoreOrder, differences)
+		}
+		return differences
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		differences = append(differences, fmt.Sprintf("%s: expected %s, got %s", path, describe(expected), describe(actual)))
+	}
+	return differences
+}
+
+func sortedKeys(maps ...map[string]interface{}) []string {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, m := range maps {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedByEncoding returns a copy of elements sorted by their canonical JSON
+// encoding, so neither ignored fields nor the order of nested arrays affect
+// the order
+func sortedByEncoding(elements []interface{}, ignored map[string]bool) []interface{} {
+	type encoded struct {
+		key     string
+		element interface{}
+	}
+	encodedElements := make([]encoded, len(elements))
+	for i, element := range elements {
+		bytes, _ := json.Marshal(canonical(element, ignored))
+		encodedElements[i] = encoded{key: string(bytes), element: element}
+	}
+	sort.SliceStable(encodedElements, func(i, j int) bool {
+		return encodedElements[i].key < encodedElements[j].key
+	})
+	sorted := make([]interface{}, len(elements))
+	for i, e := range encodedElements {
+		sorted[i] = e.element
+	}
+	return sorted
+}
+
+// canonical strips ignored fields from a JSON value and sorts its arrays
+func canonical(value interface{}, ignored map[string]bool) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		stripped := make(map[string]interface{}, len(value))
+		for key, v := range value {
+			if !ignored[key] {
+				stripped[key] = canonical(v, ignored)
+			}
+		}
+		return stripped
+	case []interface{}:
+		sorted := sortedByEncoding(value, ignored)
+		for i := range sorted {
+			sorted[i] = canonical(sorted[i], ignored)
+		}
+		return sorted
+	}
+	return value
+}
+
+func describe(value interface{}) string {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(bytes)
+}
@@ -0,0 +1,93 @@
+package recordings
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"path":"` + r.URL.Path + `","body":"` + string(body) + `"}`))
+	})
+
+	var recording bytes.Buffer
+	recorded := httptest.NewServer(NewRecorder(&recording).Handler(handler))
+	defer recorded.Close()
+
+	response, err := http.Post(recorded.URL+"/api/v1/identifier?x=1", "application/x-www-form-urlencoded", strings.NewReader("value=42"))
+	require.Nil(t, err)
+	body, err := io.ReadAll(response.Body)
+	require.Nil(t, err)
+	response.Body.Close()
+	require.Equal(t, `{"path":"/api/v1/identifier","body":"value=42"}`, string(body))
+
+	path := filepath.Join(t.TempDir(), "recording.jsonl")
+	require.Nil(t, os.WriteFile(path, recording.Bytes(), 0644))
+	exchanges, err := ReadFile(path)
+	require.Nil(t, err)
+	require.Len(t, exchanges, 1)
+	require.Equal(t, Request{
+		Method:      "POST",
+		URL:         "/api/v1/identifier?x=1",
+		ContentType: "application/x-www-form-urlencoded",
+		Body:        "value=42",
+	}, exchanges[0].Request)
+	require.Equal(t, http.StatusCreated, exchanges[0].Response.Status)
+	require.Equal(t, string(body), exchanges[0].Response.Body)
+
+	replayed := httptest.NewServer(handler)
+	defer replayed.Close()
+	baseURL, err := url.Parse(replayed.URL)
+	require.Nil(t, err)
+
+	replayedResponse, err := Replay(replayed.Client(), baseURL, exchanges[0].Request)
+	require.Nil(t, err)
+	require.Empty(t, Diff(&exchanges[0].Response, replayedResponse, DiffOptions{}))
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("it reports status and JSON differences by path", func(t *testing.T) {
+		expected := &Response{Status: 200, Body: `{"visitor":{"id":"a","assignments":[{"split_name":"foo","variant":"control"}]}}`}
+		actual := &Response{Status: 201, Body: `{"visitor":{"id":"b","assignments":[{"split_name":"foo","variant":"treatment"}],"extra":true}}`}
+
+		require.Equal(t, []string{
+			"status: expected 200, got 201",
+			`$.visitor.assignments[0].variant: expected "control", got "treatment"`,
+			"$.visitor.extra: unexpected true",
+			`$.visitor.id: expected "a", got "b"`,
+		}, Diff(expected, actual, DiffOptions{}))
+	})
+
+	t.Run("it ignores fields at any depth", func(t *testing.T) {
+		expected := &Response{Status: 200, Body: `{"id":"a","visitor":{"id":"a"}}`}
+		actual := &Response{Status: 200, Body: `{"id":"b","visitor":{"id":"b"}}`}
+
+		require.Empty(t, Diff(expected, actual, DiffOptions{IgnoreFields: []string{"id"}}))
+	})
+
+	t.Run("it compares arrays in order unless told not to", func(t *testing.T) {
+		expected := &Response{Status: 200, Body: `{"splits":[{"name":"a","variants":["x","y"]},{"name":"b"}]}`}
+		actual := &Response{Status: 200, Body: `{"splits":[{"name":"b"},{"name":"a","variants":["y","x"]}]}`}
+
+		require.NotEmpty(t, Diff(expected, actual, DiffOptions{}))
+		require.Empty(t, Diff(expected, actual, DiffOptions{IgnoreOrder: true}))
+	})
+
+	t.Run("it compares non-JSON bodies byte for byte", func(t *testing.T) {
+		expected := &Response{Status: 204}
+		require.Empty(t, Diff(expected, &Response{Status: 204}, DiffOptions{}))
+		require.Equal(t, []string{`body: expected "", got "oops"`}, Diff(expected, &Response{Status: 204, Body: "oops"}, DiffOptions{}))
+	})
+}
@@ -16,10 +16,11 @@ type RemoteKill struct {
 	overrideTo       *string
 	firstBadVersion  *string
 	fixedVersion     *string
+	expiresAt        *string
 }
 
 // New returns a migration object
-func New(split, reason, overrideTo, firstBadVersion, fixedVersion *string) (migrations.IMigration, error) {
+func New(split, reason, overrideTo, firstBadVersion, fixedVersion, expiresAt *string) (migrations.IMigration, error) {
 	migrationVersion, err := migrations.GenerateMigrationVersion()
 	if err != nil {
 		return nil, err
@@ -32,6 +33,7 @@ func New(split, reason, overrideTo, firstBadVersion, fixedVersion *string) (migr
 		overrideTo:       overrideTo,
 		firstBadVersion:  firstBadVersion,
 		fixedVersion:     fixedVersion,
+		expiresAt:        expiresAt,
 	}, nil
 }
 
@@ -44,6 +46,7 @@ func FromFile(migrationVersion *string, serializable *serializers.RemoteKill) mi
 		overrideTo:       serializable.OverrideTo,
 		firstBadVersion:  serializable.FirstBadVersion,
 		fixedVersion:     serializable.FixedVersion,
+		expiresAt:        serializable.ExpiresAt,
 	}
 }
 
@@ -74,6 +77,11 @@ func (r *RemoteKill) Validate() error {
 		return err
 	}
 
+	err = validations.OptionalDate("expires_at", r.expiresAt)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -101,9 +109,12 @@ func (r *RemoteKill) SyncPath() string {
 	return "api/v2/migrations/app_remote_kill"
 }
 
-// Serializable returns a JSON serializable representation
+// Serializable returns a JSON serializable representation. Expiry is
+// enforced by the CLI, so it isn't sent to the server.
 func (r *RemoteKill) Serializable() interface{} {
-	return r.serializable()
+	serializable := r.serializable()
+	serializable.ExpiresAt = nil
+	return serializable
 }
 
 func (r *RemoteKill) serializable() *serializers.RemoteKill {
@@ -113,6 +124,7 @@ func (r *RemoteKill) serializable() *serializers.RemoteKill {
 		OverrideTo:      r.overrideTo,
 		FirstBadVersion: r.firstBadVersion,
 		FixedVersion:    r.fixedVersion,
+		ExpiresAt:       r.expiresAt,
 	}
 }
 
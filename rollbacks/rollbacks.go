@@ -0,0 +1,148 @@
+package rollbacks
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Betterment/testtrack-cli/featurecompletions"
+	"github.com/Betterment/testtrack-cli/migrations"
+	"github.com/Betterment/testtrack-cli/ownershiptransfers"
+	"github.com/Betterment/testtrack-cli/remotekills"
+	"github.com/Betterment/testtrack-cli/schema"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/splitdecisions"
+	"github.com/Betterment/testtrack-cli/splitretirements"
+	"github.com/Betterment/testtrack-cli/splits"
+)
+
+// Plan returns a migration that compensates for the migration at a version by
+// restoring the state its resource was in before the migration. decision is
+// used when rolling back a split's creation requires retiring it and no prior
+// retirement says which variant clients in the field should see.
+func Plan(version string, migrationRepo migrations.Repository, decision string, force bool) (migrations.IMigration, error) {
+	migration, ok := migrationRepo[version]
+	if !ok {
+		return nil, fmt.Errorf("migration %s not found in testtrack/migrate", version)
+	}
+
+	if !force {
+		later := laterMigrationsOfResource(migration, version, migrationRepo)
+		if len(later) != 0 {
+			return nil, fmt.Errorf("migrations %v also affect %s and would be undone too - use --force to roll back anyway", later, migration.File().Resource())
+		}
+	}
+
+	prior, err := schema.Replay(migrationRepo, version)
+	if err != nil {
+		return nil, err
+	}
+
+	file := migration.File()
+	switch {
+	case file.Split != nil:
+		return planSplit(file.Split.Name, prior, version, migrationRepo, decision)
+	case file.SplitDecision != nil:
+		return planSplit(file.SplitDecision.Split, prior, version, migrationRepo, decision)
+	case file.SplitRetirement != nil:
+		return planSplit(file.SplitRetirement.Split, prior, version, migrationRepo, decision)
+	case file.RemoteKill != nil:
+		return planRemoteKill(file.RemoteKill, prior)
+	case file.FeatureCompletion != nil:
+		return planFeatureCompletion(file.FeatureCompletion, prior)
+	case file.OwnershipTransfer != nil:
+		return planOwnershipTransfer(file.OwnershipTransfer.Split, prior)
+	case file.IdentifierType != nil:
+		return nil, errors.New("identifier_type migrations can't be rolled back")
+	}
+	return nil, fmt.Errorf("migration %s didn't match a known migration type", version)
+}
+
+func laterMigrationsOfResource(migration migrations.IMigration, version string, migrationRepo migrations.Repository) []string {
+	later := []string{}
+	for _, candidate := range migrationRepo.SortedVersions() {
+		if candidate > version && migrationRepo[candidate].SameResourceAs(migration) {
+			later = append(later, candidate)
+		}
+	}
+	return later
+}
+
+func planSplit(name string, prior *serializers.Schema, version string, migrationRepo migrations.Repository, decision string) (migrations.IMigration, error) {
+	for _, priorSplit := range prior.Splits {
+		if priorSplit.Name != name {
+			continue
+		}
+		weights := splits.Weights(priorSplit.Weights)
+		if priorSplit.Decided {
+			variant, _ := weights.DecidedVariant()
+			return splitdecisions.New(&name, &variant)
+		}
+		owner := priorSplit.Owner
+		return splits.New(&name, weights.Copy(), &owner, priorSplit.Details)
+	}
+
+	// The split didn't exist before the migration, so it has to be retired
+	if decision == "" {
+		decision = priorRetirementDecision(name, version, migrationRepo)
+	}
+	if decision == "" {
+		return nil, fmt.Errorf("split %s didn't exist before migration %s, so rolling back retires it - use --decision to choose the variant clients in the field should see", name, version)
+	}
+	return splitretirements.New(&name, &decision)
+}
+
+func planRemoteKill(remoteKill *serializers.RemoteKill, prior *serializers.Schema) (migrations.IMigration, error) {
+	split := remoteKill.Split
+	reason := remoteKill.Reason
+	for _, priorKill := range prior.RemoteKills {
+		if priorKill.Split == split && priorKill.Reason == reason {
+			return remotekills.New(&split, &reason, priorKill.OverrideTo, priorKill.FirstBadVersion, priorKill.FixedVersion, priorKill.ExpiresAt)
+		}
+	}
+	if remoteKill.FirstBadVersion == nil {
+		return nil, fmt.Errorf("remote_kill %s of %s didn't exist before it was destroyed, so there's nothing to restore", reason, split)
+	}
+	return remotekills.New(&split, &reason, nil, nil, nil, nil)
+}
+
+func planFeatureCompletion(featureCompletion *serializers.FeatureCompletion, prior *serializers.Schema) (migrations.IMigration, error) {
+	featureGate := featureCompletion.FeatureGate
+	for _, priorCompletion := range prior.FeatureCompletions {
+		if priorCompletion.FeatureGate == featureGate {
+			return featurecompletions.New(&featureGate, priorCompletion.Version, priorCompletion.ExpiresAt)
+		}
+	}
+	if featureCompletion.Version == nil {
+		return nil, fmt.Errorf("feature_completion of %s didn't exist before it was destroyed, so there's nothing to restore", featureGate)
+	}
+	return featurecompletions.New(&featureGate, nil, nil)
+}
+
+func planOwnershipTransfer(name string, prior *serializers.Schema) (migrations.IMigration, error) {
+	for _, priorSplit := range prior.Splits {
+		if priorSplit.Name == name {
+			if priorSplit.Owner == "" {
+				return nil, fmt.Errorf("split %s had no owner before the transfer, so there's no owner to restore", name)
+			}
+			owner := priorSplit.Owner
+			return ownershiptransfers.New(&name, &owner)
+		}
+	}
+	return nil, fmt.Errorf("split %s didn't exist before the transfer, so there's no owner to restore", name)
+}
+
+// priorRetirementDecision returns the decision of the most recent retirement
+// of a split before a version, if any
+func priorRetirementDecision(name, version string, migrationRepo migrations.Repository) string {
+	versions := migrationRepo.SortedVersions()
+	for i := len(versions) - 1; i >= 0; i-- {
+		if versions[i] >= version {
+			continue
+		}
+		retirement := migrationRepo[versions[i]].File().SplitRetirement
+		if retirement != nil && retirement.Split == name {
+			return retirement.Decision
+		}
+	}
+	return ""
+}
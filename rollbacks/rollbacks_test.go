@@ -0,0 +1,211 @@
+package rollbacks_test
+
+import (
+	"testing"
+
+	"github.com/Betterment/testtrack-cli/internal/testutil"
+	"github.com/Betterment/testtrack-cli/rollbacks"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/splits"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan(t *testing.T) {
+	experiment := "my_app.fancy_experiment"
+	featureGate := "my_app.fancy_enabled"
+	weights := splits.Weights{"control": 50, "treatment": 50}
+	reweighted := splits.Weights{"control": 60, "treatment": 40}
+	gateWeights := splits.Weights{"false": 100, "true": 0}
+	overrideTo, firstBadVersion, appVersion := "control", "1.0", "2.0"
+
+	cases := []struct {
+		desc     string
+		build    func(f *testutil.RepoBuilder)
+		version  string
+		decision string
+		force    bool
+		want     *serializers.MigrationFile
+		err      string
+	}{
+		{
+			desc: "it restores the prior weights of a reweighted split",
+			build: func(f *testutil.RepoBuilder) {
+				f.Split("2020010100001", experiment, "growth", weights)
+				f.Split("2020010100002", experiment, "", reweighted)
+			},
+			version: "2020010100002",
+			want: &serializers.MigrationFile{
+				SerializerVersion: serializers.SerializerVersion,
+				Split:             &serializers.SplitYAML{Name: experiment, Weights: weights, Owner: "growth"},
+			},
+		},
+		{
+			desc: "it un-decides a split by restoring its prior weights",
+			build: func(f *testutil.RepoBuilder) {
+				f.Split("2020010100001", experiment, "growth", weights)
+				f.Decision("2020010100002", experiment, "treatment")
+			},
+			version: "2020010100002",
+			want: &serializers.MigrationFile{
+				SerializerVersion: serializers.SerializerVersion,
+				Split:             &serializers.SplitYAML{Name: experiment, Weights: weights, Owner: "growth"},
+			},
+		},
+		{
+			desc: "it restores a prior decision",
+			build: func(f *testutil.RepoBuilder) {
+				f.Split("2020010100001", experiment, "growth", weights)
+				f.Decision("2020010100002", experiment, "control")
+				f.Decision("2020010100003", experiment, "treatment")
+			},
+			version: "2020010100003",
+			want: &serializers.MigrationFile{
+				SerializerVersion: serializers.SerializerVersion,
+				SplitDecision:     &serializers.SplitDecision{Split: experiment, Variant: "control"},
+			},
+		},
+		{
+			desc: "it retires a revived split with the decision of its prior retirement",
+			build: func(f *testutil.RepoBuilder) {
+				f.Split("2020010100001", experiment, "growth", weights)
+				f.Retirement("2020010100002", experiment, "control")
+				f.Split("2020010100003", experiment, "growth", weights)
+			},
+			version: "2020010100003",
+			want: &serializers.MigrationFile{
+				SerializerVersion: serializers.SerializerVersion,
+				SplitRetirement:   &serializers.SplitRetirement{Split: experiment, Decision: "control"},
+			},
+		},
+		{
+			desc: "it retires a created split with the provided decision",
+			build: func(f *testutil.RepoBuilder) {
+				f.Split("2020010100001", experiment, "growth", weights)
+			},
+			version:  "2020010100001",
+			decision: "treatment",
+			want: &serializers.MigrationFile{
+				SerializerVersion: serializers.SerializerVersion,
+				SplitRetirement:   &serializers.SplitRetirement{Split: experiment, Decision: "treatment"},
+			},
+		},
+		{
+			desc: "it requires a decision to retire a created split",
+			build: func(f *testutil.RepoBuilder) {
+				f.Split("2020010100001", experiment, "growth", weights)
+			},
+			version: "2020010100001",
+			err:     "split my_app.fancy_experiment didn't exist before migration 2020010100001, so rolling back retires it - use --decision to choose the variant clients in the field should see",
+		},
+		{
+			desc: "it refuses to undo later migrations of the same resource without force",
+			build: func(f *testutil.RepoBuilder) {
+				f.Split("2020010100001", experiment, "growth", weights)
+				f.Split("2020010100002", experiment, "", reweighted)
+				f.Decision("2020010100003", experiment, "control")
+			},
+			version: "2020010100002",
+			err:     "migrations [2020010100003] also affect my_app.fancy_experiment and would be undone too - use --force to roll back anyway",
+		},
+		{
+			desc: "it undoes later migrations of the same resource with force",
+			build: func(f *testutil.RepoBuilder) {
+				f.Split("2020010100001", experiment, "growth", weights)
+				f.Split("2020010100002", experiment, "", reweighted)
+				f.Decision("2020010100003", experiment, "control")
+			},
+			version: "2020010100002",
+			force:   true,
+			want: &serializers.MigrationFile{
+				SerializerVersion: serializers.SerializerVersion,
+				Split:             &serializers.SplitYAML{Name: experiment, Weights: weights, Owner: "growth"},
+			},
+		},
+		{
+			desc: "it destroys a remote kill the migration created",
+			build: func(f *testutil.RepoBuilder) {
+				f.Split("2020010100001", experiment, "growth", weights)
+				f.RemoteKill("2020010100002", experiment, "crash", &overrideTo, &firstBadVersion, nil)
+			},
+			version: "2020010100002",
+			want: &serializers.MigrationFile{
+				SerializerVersion: serializers.SerializerVersion,
+				RemoteKill:        &serializers.RemoteKill{Split: experiment, Reason: "crash"},
+			},
+		},
+		{
+			desc: "it restores a destroyed remote kill",
+			build: func(f *testutil.RepoBuilder) {
+				f.Split("2020010100001", experiment, "growth", weights)
+				f.RemoteKill("2020010100002", experiment, "crash", &overrideTo, &firstBadVersion, nil)
+				f.RemoteKill("2020010100003", experiment, "crash", nil, nil, nil)
+			},
+			version: "2020010100003",
+			want: &serializers.MigrationFile{
+				SerializerVersion: serializers.SerializerVersion,
+				RemoteKill:        &serializers.RemoteKill{Split: experiment, Reason: "crash", OverrideTo: &overrideTo, FirstBadVersion: &firstBadVersion},
+			},
+		},
+		{
+			desc: "it destroys a feature completion the migration created",
+			build: func(f *testutil.RepoBuilder) {
+				f.Split("2020010100001", featureGate, "growth", gateWeights)
+				f.FeatureCompletion("2020010100002", featureGate, &appVersion)
+			},
+			version: "2020010100002",
+			want: &serializers.MigrationFile{
+				SerializerVersion: serializers.SerializerVersion,
+				FeatureCompletion: &serializers.FeatureCompletion{FeatureGate: featureGate},
+			},
+		},
+		{
+			desc: "it restores a destroyed feature completion",
+			build: func(f *testutil.RepoBuilder) {
+				f.Split("2020010100001", featureGate, "growth", gateWeights)
+				f.FeatureCompletion("2020010100002", featureGate, &appVersion)
+				f.FeatureCompletion("2020010100003", featureGate, nil)
+			},
+			version: "2020010100003",
+			want: &serializers.MigrationFile{
+				SerializerVersion: serializers.SerializerVersion,
+				FeatureCompletion: &serializers.FeatureCompletion{FeatureGate: featureGate, Version: &appVersion},
+			},
+		},
+		{
+			desc: "it restores the owner before a transfer",
+			build: func(f *testutil.RepoBuilder) {
+				f.Split("2020010100001", experiment, "growth", weights)
+				f.OwnershipTransfer("2020010100002", experiment, "checkout")
+			},
+			version: "2020010100002",
+			want: &serializers.MigrationFile{
+				SerializerVersion: serializers.SerializerVersion,
+				OwnershipTransfer: &serializers.OwnershipTransfer{Split: experiment, Owner: "growth"},
+			},
+		},
+		{
+			desc: "it rejects unknown versions",
+			build: func(f *testutil.RepoBuilder) {
+				f.Split("2020010100001", experiment, "growth", weights)
+			},
+			version: "2020010199999",
+			err:     "migration 2020010199999 not found in testtrack/migrate",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			fixtures := testutil.NewRepoBuilder(t)
+			c.build(fixtures)
+
+			migration, err := rollbacks.Plan(c.version, fixtures.Repo, c.decision, c.force)
+			if c.err != "" {
+				require.EqualError(t, err, c.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.want, migration.File())
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/splits"
+)
+
+// LinkedSchema describes a schema linked at ~/.testtrack/schemas
+type LinkedSchema struct {
+	Path string `json:"path"`
+	// Target is where the link points, or empty if it's a plain file
+	Target string `json:"target,omitempty"`
+	// Broken links point at a schema that no longer exists, e.g. because the
+	// app was uninstalled, and are skipped when merging
+	Broken bool `json:"broken"`
+}
+
+// Conflict describes a resource that linked schemas define differently. The
+// definition from the first file, in alphabetical order, wins.
+type Conflict struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	Files  []string `json:"files"`
+	Detail string   `json:"detail"`
+}
+
+// Links lists the schemas linked at ~/.testtrack/schemas
+func Links() ([]LinkedSchema, error) {
+	paths, err := linkedSchemaPaths()
+	if err != nil {
+		return nil, err
+	}
+	links := []LinkedSchema{}
+	for _, path := range paths {
+		link := LinkedSchema{Path: path}
+		info, err := os.Lstat(path)
+		if err != nil {
+			return nil, err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			link.Target, err = os.Readlink(path)
+			if err != nil {
+				return nil, err
+			}
+		}
+		_, err = os.Stat(path)
+		if os.IsNotExist(err) {
+			link.Broken = true
+		} else if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+// ReadMergedWithConflicts merges schemas linked at ~/.testtrack/schemas like
+// ReadMerged, and also returns the resources they define differently
+func ReadMergedWithConflicts() (*serializers.Schema, []Conflict, error) {
+	paths, err := linkedSchemaPaths()
+	if err != nil {
+		return nil, nil, err
+	}
+	m := newMerger()
+	for _, path := range paths {
+		schema, err := readLinked(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if schema != nil {
+			m.merge(path, schema)
+		}
+	}
+	return &m.schema, m.sortedConflicts(), nil
+}
+
+// merger merges schemas, keeping one copy of resources defined by more than
+// one schema and recording conflicts where their definitions differ
+type merger struct {
+	schema    serializers.Schema
+	sources   map[string]mergedResource
+	conflicts map[string]*Conflict
+}
+
+// mergedResource locates the merged copy of a resource and the file it came
+// from
+type mergedResource struct {
+	index int
+	path  string
+}
+
+func newMerger() *merger {
+	return &merger{
+		sources:   map[string]mergedResource{},
+		conflicts: map[string]*Conflict{},
+	}
+}
+
+func (m *merger) merge(path string, schema *serializers.Schema) {
+	for _, split := range schema.Splits {
+		i := m.index("split", split.Name, path, len(m.schema.Splits))
+		if i < 0 {
+			m.schema.Splits = append(m.schema.Splits, split)
+		} else if !reflect.DeepEqual(m.schema.Splits[i], split) {
+			m.conflict("split", split.Name, path, splitConflictDetail(m.schema.Splits[i], split))
+		}
+	}
+	for _, identifierType := range schema.IdentifierTypes {
+		// Identifier types have nothing but a name, so they can't conflict
+		if m.index("identifier_type", identifierType.Name, path, len(m.schema.IdentifierTypes)) < 0 {
+			m.schema.IdentifierTypes = append(m.schema.IdentifierTypes, identifierType)
+		}
+	}
+	for _, remoteKill := range schema.RemoteKills {
+		name := remoteKill.Split + ":" + remoteKill.Reason
+		i := m.index("remote_kill", name, path, len(m.schema.RemoteKills))
+		if i < 0 {
+			m.schema.RemoteKills = append(m.schema.RemoteKills, remoteKill)
+		} else if !reflect.DeepEqual(m.schema.RemoteKills[i], remoteKill) {
+			m.conflict("remote_kill", name, path, "override_to or versions differ")
+		}
+	}
+	for _, featureCompletion := range schema.FeatureCompletions {
+		i := m.index("feature_completion", featureCompletion.FeatureGate, path, len(m.schema.FeatureCompletions))
+		if i < 0 {
+			m.schema.FeatureCompletions = append(m.schema.FeatureCompletions, featureCompletion)
+		} else if !reflect.DeepEqual(m.schema.FeatureCompletions[i], featureCompletion) {
+			m.conflict("feature_completion", featureCompletion.FeatureGate, path, "versions differ")
+		}
+	}
+}
+
+// index returns the index of a resource already merged, or -1 after noting
+// that the resource will be appended at next
+func (m *merger) index(kind, name, path string, next int) int {
+	key := kind + ":" + name
+	if source, ok := m.sources[key]; ok {
+		return source.index
+	}
+	m.sources[key] = mergedResource{index: next, path: path}
+	return -1
+}
+
+func (m *merger) conflict(kind, name, path, detail string) {
+	key := kind + ":" + name
+	conflict, ok := m.conflicts[key]
+	if !ok {
+		conflict = &Conflict{
+			Kind:   kind,
+			Name:   name,
+			Files:  []string{m.sources[key].path},
+			Detail: detail,
+		}
+		m.conflicts[key] = conflict
+	}
+	conflict.Files = append(conflict.Files, path)
+}
+
+func (m *merger) sortedConflicts() []Conflict {
+	conflicts := make([]Conflict, 0, len(m.conflicts))
+	for _, conflict := range m.conflicts {
+		conflicts = append(conflicts, *conflict)
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Kind != conflicts[j].Kind {
+			return conflicts[i].Kind < conflicts[j].Kind
+		}
+		return conflicts[i].Name < conflicts[j].Name
+	})
+	return conflicts
+}
+
+func splitConflictDetail(a, b serializers.SchemaSplit) string {
+	switch {
+	case !reflect.DeepEqual(a.Weights, b.Weights):
+		aWeights, bWeights := splits.Weights(a.Weights), splits.Weights(b.Weights)
+		return fmt.Sprintf("weights differ: %s vs %s", aWeights.String(), bWeights.String())
+	case a.Decided != b.Decided:
+		return "decided differs"
+	case a.Owner != b.Owner:
+		return fmt.Sprintf("owner differs: %s vs %s", a.Owner, b.Owner)
+	}
+	return "details differ"
+}
+
+// readLinked reads a linked schema, returning nil if the link is broken or
+// the schema was written by a newer testtrack, so one app can't take down the
+// schemas of the others
+func readLinked(path string) (*serializers.Schema, error) {
+	_, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, nil // It's OK if this file doesn't exist (e.g. broken symlink, app was uninstalled), we'll just skip it.
+	}
+	if err != nil {
+		return nil, err
+	}
+	schema, err := readFile(path)
+	if errors.Is(err, serializers.ErrNewerSerializerVersion) {
+		fmt.Fprintf(os.Stderr, "Warning: skipping linked schema: %s\n", err)
+		return nil, nil
+	}
+	return schema, err
+}
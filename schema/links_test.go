@@ -0,0 +1,96 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/stretchr/testify/require"
+)
+
+var appASchema = `
+serializer_version: 2
+schema_version: "2020011774023"
+identifier_types:
+- name: user_id
+splits:
+- name: shared.checkout_experiment
+  weights:
+    control: 50
+    treatment: 50
+- name: shared.banner_enabled
+  weights:
+    "false": 0
+    "true": 100
+- name: a.only_experiment
+  weights:
+    control: 50
+    treatment: 50
+`
+
+var appBSchema = `
+serializer_version: 2
+schema_version: "2020011774023"
+identifier_types:
+- name: user_id
+splits:
+- name: shared.checkout_experiment
+  weights:
+    control: 90
+    treatment: 10
+- name: shared.banner_enabled
+  weights:
+    "false": 0
+    "true": 100
+`
+
+func TestLinks(t *testing.T) {
+	configDir := t.TempDir()
+	schemasDir := filepath.Join(configDir, "schemas")
+	require.Nil(t, os.MkdirAll(schemasDir, 0755))
+	t.Setenv("TESTTRACK_FAKE_SERVER_CONFIG_DIR", configDir)
+
+	projectDir := t.TempDir()
+	appBPath := filepath.Join(projectDir, "schema.yml")
+	require.Nil(t, os.WriteFile(appBPath, []byte(appBSchema), 0644))
+	require.Nil(t, os.WriteFile(filepath.Join(schemasDir, "a.yml"), []byte(appASchema), 0644))
+	require.Nil(t, os.Symlink(appBPath, filepath.Join(schemasDir, "b.yml")))
+	require.Nil(t, os.Symlink(filepath.Join(projectDir, "missing.yml"), filepath.Join(schemasDir, "c.yml")))
+
+	t.Run("it lists links and broken links", func(t *testing.T) {
+		links, err := Links()
+		require.Nil(t, err)
+		require.Equal(t, []LinkedSchema{
+			{Path: filepath.Join(schemasDir, "a.yml")},
+			{Path: filepath.Join(schemasDir, "b.yml"), Target: appBPath},
+			{Path: filepath.Join(schemasDir, "c.yml"), Target: filepath.Join(projectDir, "missing.yml"), Broken: true},
+		}, links)
+	})
+
+	t.Run("it merges identical resources once and reports conflicts", func(t *testing.T) {
+		mergedSchema, conflicts, err := ReadMergedWithConflicts()
+		require.Nil(t, err)
+
+		require.Equal(t, []serializers.IdentifierType{{Name: "user_id"}}, mergedSchema.IdentifierTypes)
+		require.Len(t, mergedSchema.Splits, 3)
+		require.Equal(t, "shared.checkout_experiment", mergedSchema.Splits[0].Name)
+		require.Equal(t, 50, mergedSchema.Splits[0].Weights["control"])
+
+		require.Equal(t, []Conflict{
+			{
+				Kind:   "split",
+				Name:   "shared.checkout_experiment",
+				Files:  []string{filepath.Join(schemasDir, "a.yml"), filepath.Join(schemasDir, "b.yml")},
+				Detail: "weights differ: control: 50, treatment: 50 vs control: 90, treatment: 10",
+			},
+		}, conflicts)
+	})
+	t.Run("it skips schemas written by a newer testtrack", func(t *testing.T) {
+		require.Nil(t, os.WriteFile(filepath.Join(schemasDir, "d.yml"), []byte("serializer_version: 99\nsplits:\n- name: d.future_experiment\n"), 0644))
+
+		mergedSchema, _, err := ReadMergedWithConflicts()
+		require.Nil(t, err)
+		require.Len(t, mergedSchema.Splits, 3)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"sort"
 
 	"github.com/Betterment/testtrack-cli/migrationloaders"
+	"github.com/Betterment/testtrack-cli/migrations"
 	"github.com/Betterment/testtrack-cli/paths"
 	"github.com/Betterment/testtrack-cli/serializers"
 	"github.com/Betterment/testtrack-cli/splits"
@@ -27,12 +28,30 @@ func findSchemaPath() (string, bool) {
 	return "testtrack/schema.json", false
 }
 
+// Path returns the path to the schema file and whether it exists
+func Path() (string, bool) {
+	return findSchemaPath()
+}
+
 // Read a schema from disk or generate one
 func Read() (*serializers.Schema, error) {
 	schemaPath, exists := findSchemaPath()
 	if !exists {
 		return Generate()
 	}
+	return readFile(schemaPath)
+}
+
+// ReadCommitted reads the schema from disk without generating it if missing
+func ReadCommitted() (*serializers.Schema, error) {
+	schemaPath, exists := findSchemaPath()
+	if !exists {
+		return nil, errors.New("testtrack/schema.{json,yml} does not exist. Run testtrack schema generate to create it")
+	}
+	return readFile(schemaPath)
+}
+
+func readFile(schemaPath string) (*serializers.Schema, error) {
 	schemaBytes, err := os.ReadFile(schemaPath)
 	if err != nil {
 		return nil, err
@@ -42,17 +61,20 @@ func Read() (*serializers.Schema, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = serializers.CheckSerializerVersion(schemaPath, schema.SerializerVersion)
+	if err != nil {
+		return nil, err
+	}
 	return &schema, nil
 }
 
 // Generate a schema from migrations on the filesystem and write it to disk
 func Generate() (*serializers.Schema, error) {
-	schema := &serializers.Schema{SerializerVersion: serializers.SerializerVersion}
-	err := mergeLegacySchema(schema)
+	migrationRepo, err := migrationloaders.Load()
 	if err != nil {
 		return nil, err
 	}
-	err = applyAllMigrationsToSchema(schema)
+	schema, err := Replay(migrationRepo, "")
 	if err != nil {
 		return nil, err
 	}
@@ -63,6 +85,22 @@ func Generate() (*serializers.Schema, error) {
 	return schema, nil
 }
 
+// Replay builds a schema in memory from the legacy schema, if any, and the
+// migrations in the repo with versions before the provided version, or all
+// of them if the version is empty
+func Replay(migrationRepo migrations.Repository, before string) (*serializers.Schema, error) {
+	schema := &serializers.Schema{SerializerVersion: serializers.SerializerVersion}
+	err := mergeLegacySchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	err = applyMigrationsToSchema(schema, migrationRepo, before)
+	if err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
 // Write a schema to disk after alpha-sorting its resources
 func Write(schema *serializers.Schema) error {
 	SortAlphabetically(schema)
@@ -115,38 +153,20 @@ func Link(force bool) error {
 	return os.Symlink(dir+"/"+schemaPath, path)
 }
 
-// ReadMerged merges schemas linked at ~/testtrack/schemas into a single virtual schema
-func ReadMerged() (*serializers.Schema, error) {
+func linkedSchemaPaths() ([]string, error) {
 	configDir, err := paths.FakeServerConfigDir()
 	if err != nil {
 		return nil, err
 	}
-	paths, err := filepath.Glob(*configDir + "/schemas/*.*")
-	if err != nil {
-		return nil, err
-	}
-	var mergedSchema serializers.Schema
-	for _, path := range paths {
-		schemaBytes, err := os.ReadFile(path)
-		if err != nil {
-			if os.IsNotExist(err) {
-				continue // It's OK if this file doesn't exist (e.g. broken symlink, app was uninstalled), we'll just skip it.
-			}
-			return nil, err
-		}
+	return filepath.Glob(*configDir + "/schemas/*.*")
+}
 
-		var schema serializers.Schema
-		err = yaml.Unmarshal(schemaBytes, &schema)
-		if err != nil {
-			return nil, err
-		}
-		// Merge into master schema
-		mergedSchema.Splits = append(mergedSchema.Splits, schema.Splits...)
-		mergedSchema.FeatureCompletions = append(mergedSchema.FeatureCompletions, schema.FeatureCompletions...)
-		mergedSchema.RemoteKills = append(mergedSchema.RemoteKills, schema.RemoteKills...)
-		mergedSchema.IdentifierTypes = append(mergedSchema.IdentifierTypes, schema.IdentifierTypes...)
-	}
-	return &mergedSchema, nil
+// ReadMerged merges schemas linked at ~/testtrack/schemas into a single virtual
+// schema. Resources defined by more than one linked schema are merged once;
+// see ReadMergedWithConflicts for those whose definitions differ.
+func ReadMerged() (*serializers.Schema, error) {
+	mergedSchema, _, err := ReadMergedWithConflicts()
+	return mergedSchema, err
 }
 
 func mergeLegacySchema(schema *serializers.Schema) error {
@@ -190,16 +210,14 @@ func mergeLegacySchema(schema *serializers.Schema) error {
 	return nil
 }
 
-func applyAllMigrationsToSchema(schema *serializers.Schema) error {
-	migrationRepo, err := migrationloaders.Load()
-	if err != nil {
-		return err
-	}
-
+func applyMigrationsToSchema(schema *serializers.Schema, migrationRepo migrations.Repository, before string) error {
 	versions := migrationRepo.SortedVersions()
+	if before != "" {
+		versions = versions[:sort.SearchStrings(versions, before)]
+	}
 
 	for _, version := range versions {
-		err = migrationRepo[version].ApplyToSchema(schema, migrationRepo, false)
+		err := migrationRepo[version].ApplyToSchema(schema, migrationRepo, false)
 		if err != nil {
 			return err
 		}
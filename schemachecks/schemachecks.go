@@ -0,0 +1,197 @@
+package schemachecks
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Betterment/testtrack-cli/migrations"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/validations"
+)
+
+// Codes identifying the kinds of problems a check can find
+const (
+	SchemaMismatch = "schema_mismatch"
+	UnknownSplit   = "unknown_split"
+	UnknownVariant = "unknown_variant"
+	UnknownOwner   = "unknown_owner"
+	Expired        = "expired"
+)
+
+// Problem describes a single inconsistency found in a project's TestTrack
+// configuration
+type Problem struct {
+	Code     string `json:"code"`
+	Resource string `json:"resource"`
+	Message  string `json:"message"`
+}
+
+// Check compares the committed schema against the schema replayed from
+// migrations, and checks the replayed schema's references between resources.
+// Split references are only checked if they're prefixed with appName, per ADR
+// 001, because other apps' splits can't be validated locally.
+func Check(committed, replayed *serializers.Schema, migrationRepo migrations.Repository, appName string) []Problem {
+	problems := []Problem{}
+	problems = append(problems, compareSchemas(committed, replayed)...)
+	problems = append(problems, checkReferences(replayed, migrationRepo, appName)...)
+	problems = append(problems, checkOwners(replayed)...)
+	return problems
+}
+
+func compareSchemas(committed, replayed *serializers.Schema) []Problem {
+	problems := []Problem{}
+	if committed.SchemaVersion != replayed.SchemaVersion {
+		problems = append(problems, Problem{
+			Code:     SchemaMismatch,
+			Resource: "schema_version",
+			Message:  fmt.Sprintf("schema_version is %s but latest migration is %s", committed.SchemaVersion, replayed.SchemaVersion),
+		})
+	}
+
+	committedResources := resourcesOf(committed)
+	replayedResources := resourcesOf(replayed)
+	for _, resource := range sortedKeys(committedResources, replayedResources) {
+		committedResource, inCommitted := committedResources[resource]
+		replayedResource, inReplayed := replayedResources[resource]
+		var message string
+		switch {
+		case !inReplayed:
+			message = "present in schema but not produced by migrations"
+		case !inCommitted:
+			message = "produced by migrations but missing from schema"
+		case !reflect.DeepEqual(committedResource, replayedResource):
+			message = "differs between schema and migrations"
+		default:
+			continue
+		}
+		problems = append(problems, Problem{
+			Code:     SchemaMismatch,
+			Resource: resource,
+			Message:  message,
+		})
+	}
+	return problems
+}
+
+func checkReferences(replayed *serializers.Schema, migrationRepo migrations.Repository, appName string) []Problem {
+	problems := []Problem{}
+	knownSplits := knownSplitNames(replayed, migrationRepo)
+	weights := make(map[string]map[string]int, len(replayed.Splits))
+	for _, split := range replayed.Splits {
+		weights[split.Name] = split.Weights
+	}
+
+	for _, remoteKill := range replayed.RemoteKills {
+		resource := fmt.Sprintf("remote_kill:%s:%s", remoteKill.Split, remoteKill.Reason)
+		if !ownedBy(remoteKill.Split, appName) {
+			continue
+		}
+		if !knownSplits[remoteKill.Split] {
+			problems = append(problems, Problem{
+				Code:     UnknownSplit,
+				Resource: resource,
+				Message:  fmt.Sprintf("split %s was never created", remoteKill.Split),
+			})
+			continue
+		}
+		splitWeights, ok := weights[remoteKill.Split]
+		if !ok || remoteKill.OverrideTo == nil {
+			continue // Retired splits have no weights left to check against
+		}
+		if _, ok := splitWeights[*remoteKill.OverrideTo]; !ok {
+			problems = append(problems, Problem{
+				Code:     UnknownVariant,
+				Resource: resource,
+				Message:  fmt.Sprintf("override_to variant %s is not a variant of %s", *remoteKill.OverrideTo, remoteKill.Split),
+			})
+		}
+	}
+
+	for _, featureCompletion := range replayed.FeatureCompletions {
+		if ownedBy(featureCompletion.FeatureGate, appName) && !knownSplits[featureCompletion.FeatureGate] {
+			problems = append(problems, Problem{
+				Code:     UnknownSplit,
+				Resource: "feature_completion:" + featureCompletion.FeatureGate,
+				Message:  fmt.Sprintf("feature gate %s was never created", featureCompletion.FeatureGate),
+			})
+		}
+	}
+	return problems
+}
+
+func checkOwners(replayed *serializers.Schema) []Problem {
+	problems := []Problem{}
+	for _, split := range replayed.Splits {
+		if split.Owner == "" {
+			continue // Splits created before ownership was configured have no owner
+		}
+		err := validations.ValidateOwnerName(split.Owner)
+		if err != nil {
+			problems = append(problems, Problem{
+				Code:     UnknownOwner,
+				Resource: "split:" + split.Name,
+				Message:  err.Error(),
+			})
+		}
+	}
+	return problems
+}
+
+// knownSplitNames includes splits that have since been retired, because
+// remote kills and feature completions of retired splits are still valid
+func knownSplitNames(replayed *serializers.Schema, migrationRepo migrations.Repository) map[string]bool {
+	names := make(map[string]bool)
+	for _, split := range replayed.Splits {
+		names[split.Name] = true
+	}
+	for _, migration := range migrationRepo {
+		file := migration.File()
+		switch {
+		case file.Split != nil:
+			names[file.Split.Name] = true
+		case file.SplitDecision != nil:
+			names[file.SplitDecision.Split] = true
+		case file.SplitRetirement != nil:
+			names[file.SplitRetirement.Split] = true
+		}
+	}
+	return names
+}
+
+func ownedBy(splitName, appName string) bool {
+	return strings.HasPrefix(splitName, appName+".")
+}
+
+func resourcesOf(schema *serializers.Schema) map[string]interface{} {
+	resources := make(map[string]interface{})
+	for _, split := range schema.Splits {
+		resources["split:"+split.Name] = split
+	}
+	for _, identifierType := range schema.IdentifierTypes {
+		resources["identifier_type:"+identifierType.Name] = identifierType
+	}
+	for _, remoteKill := range schema.RemoteKills {
+		resources[fmt.Sprintf("remote_kill:%s:%s", remoteKill.Split, remoteKill.Reason)] = remoteKill
+	}
+	for _, featureCompletion := range schema.FeatureCompletions {
+		resources["feature_completion:"+featureCompletion.FeatureGate] = featureCompletion
+	}
+	return resources
+}
+
+func sortedKeys(maps ...map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	keys := []string{}
+	for _, m := range maps {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,64 @@
+package schemachecks_test
+
+import (
+	"testing"
+
+	"github.com/Betterment/testtrack-cli/migrations"
+	"github.com/Betterment/testtrack-cli/schemachecks"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/splits"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	name := "my_app.retired_experiment"
+	weights := splits.Weights{"control": 50, "treatment": 50}
+	owner := ""
+	retired, err := splits.New(&name, &weights, &owner, nil)
+	require.NoError(t, err)
+	migrationRepo := migrations.Repository{*retired.MigrationVersion(): retired}
+
+	replayed := &serializers.Schema{
+		SchemaVersion: "2020010100001",
+		Splits: []serializers.SchemaSplit{
+			{Name: "my_app.fancy_experiment", Weights: map[string]int{"control": 50, "treatment": 50}},
+			{Name: "my_app.owned_enabled", Weights: map[string]int{"false": 100, "true": 0}, Owner: "nobody"},
+		},
+		RemoteKills: []serializers.RemoteKill{
+			{Split: "my_app.fancy_experiment", Reason: "bad_variant", OverrideTo: strPtr("bogus")},
+			{Split: "my_app.retired_experiment", Reason: "still_killed", OverrideTo: strPtr("control")},
+			{Split: "my_app.missing_experiment", Reason: "typo", OverrideTo: strPtr("control")},
+			{Split: "other_app.someone_elses_experiment", Reason: "cross_app", OverrideTo: strPtr("control")},
+		},
+		FeatureCompletions: []serializers.FeatureCompletion{
+			{FeatureGate: "my_app.missing_enabled", Version: strPtr("1.0")},
+		},
+	}
+	committed := &serializers.Schema{
+		SchemaVersion:      "2020010100000",
+		Splits:             replayed.Splits[:1],
+		RemoteKills:        replayed.RemoteKills,
+		FeatureCompletions: replayed.FeatureCompletions,
+	}
+
+	problems := schemachecks.Check(committed, replayed, migrationRepo, "my_app")
+
+	codes := map[string]string{}
+	for _, problem := range problems {
+		codes[problem.Resource] = problem.Code
+	}
+	require.Equal(t, map[string]string{
+		"schema_version":                                  schemachecks.SchemaMismatch,
+		"split:my_app.owned_enabled":                      schemachecks.UnknownOwner,
+		"remote_kill:my_app.fancy_experiment:bad_variant": schemachecks.UnknownVariant,
+		"remote_kill:my_app.missing_experiment:typo":      schemachecks.UnknownSplit,
+		"feature_completion:my_app.missing_enabled":       schemachecks.UnknownSplit,
+	}, codes)
+	require.Len(t, problems, 6) // owned_enabled is both missing from schema and unowned
+}
+
+func strPtr(s string) *string {
+	return &s
+}
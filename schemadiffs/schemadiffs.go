@@ -0,0 +1,108 @@
+package schemadiffs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/splits"
+)
+
+// Kinds of drift between a local schema and a remote split registry
+const (
+	MissingRemote    = "missing_remote"
+	MissingLocal     = "missing_local"
+	WeightsMismatch  = "weights_mismatch"
+	DecisionMismatch = "decision_mismatch"
+)
+
+// Drift describes a difference in a single split between the local schema
+// and the remote split registry
+type Drift struct {
+	Kind          string         `json:"kind"`
+	Split         string         `json:"split"`
+	LocalWeights  map[string]int `json:"local_weights,omitempty"`
+	RemoteWeights map[string]int `json:"remote_weights,omitempty"`
+	LocalDecided  bool           `json:"local_decided"`
+}
+
+// Diff compares the splits in a local schema against a remote split registry.
+// Remote splits are only reported missing locally if they're prefixed with
+// appName, because the registry contains every app's splits.
+func Diff(local *serializers.Schema, remote *serializers.RemoteRegistry, appName string) []Drift {
+	drifts := []Drift{}
+	localNames := make(map[string]bool, len(local.Splits))
+
+	for _, localSplit := range local.Splits {
+		localNames[localSplit.Name] = true
+		remoteSplit, ok := remote.Splits[localSplit.Name]
+		if !ok {
+			drifts = append(drifts, Drift{
+				Kind:         MissingRemote,
+				Split:        localSplit.Name,
+				LocalWeights: localSplit.Weights,
+				LocalDecided: localSplit.Decided,
+			})
+			continue
+		}
+		localWeights := splits.Weights(localSplit.Weights)
+		if localWeights.Equal(remoteSplit.Weights) {
+			continue
+		}
+		kind := WeightsMismatch
+		remoteWeights := splits.Weights(remoteSplit.Weights)
+		if _, remoteDecided := remoteWeights.DecidedVariant(); localSplit.Decided || remoteDecided {
+			kind = DecisionMismatch
+		}
+		drifts = append(drifts, Drift{
+			Kind:          kind,
+			Split:         localSplit.Name,
+			LocalWeights:  localSplit.Weights,
+			RemoteWeights: remoteSplit.Weights,
+			LocalDecided:  localSplit.Decided,
+		})
+	}
+
+	for name, remoteSplit := range remote.Splits {
+		if localNames[name] || !strings.HasPrefix(name, appName+".") {
+			continue
+		}
+		drifts = append(drifts, Drift{
+			Kind:          MissingLocal,
+			Split:         name,
+			RemoteWeights: remoteSplit.Weights,
+		})
+	}
+
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].Split < drifts[j].Split
+	})
+	return drifts
+}
+
+// Description returns a human-readable explanation of the drift
+func (d Drift) Description() string {
+	switch d.Kind {
+	case MissingRemote:
+		return "exists in local schema but not on remote"
+	case MissingLocal:
+		return fmt.Sprintf("exists on remote (%s) but not in local schema", formatWeights(d.RemoteWeights))
+	case DecisionMismatch:
+		if d.LocalDecided {
+			localWeights := splits.Weights(d.LocalWeights)
+			variant, _ := localWeights.DecidedVariant()
+			return fmt.Sprintf("decided locally on %s but remote weights are %s", variant, formatWeights(d.RemoteWeights))
+		}
+		remoteWeights := splits.Weights(d.RemoteWeights)
+		variant, _ := remoteWeights.DecidedVariant()
+		return fmt.Sprintf("undecided locally (%s) but remote is decided on %s", formatWeights(d.LocalWeights), variant)
+	default:
+		return fmt.Sprintf("weights differ (local %s; remote %s)", formatWeights(d.LocalWeights), formatWeights(d.RemoteWeights))
+	}
+}
+
+func formatWeights(weights map[string]int) string {
+	w := splits.Weights(weights)
+	return w.String()
+}
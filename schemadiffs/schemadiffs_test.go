@@ -0,0 +1,53 @@
+package schemadiffs_test
+
+import (
+	"testing"
+
+	"github.com/Betterment/testtrack-cli/schemadiffs"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	local := &serializers.Schema{
+		Splits: []serializers.SchemaSplit{
+			{Name: "my_app.in_sync_experiment", Weights: map[string]int{"control": 50, "treatment": 50}},
+			{Name: "my_app.local_only_enabled", Weights: map[string]int{"false": 100, "true": 0}},
+			{Name: "my_app.reweighted_experiment", Weights: map[string]int{"control": 50, "treatment": 50}},
+			{Name: "my_app.decided_experiment", Weights: map[string]int{"control": 0, "treatment": 100}, Decided: true},
+			{Name: "my_app.remotely_decided_experiment", Weights: map[string]int{"control": 50, "treatment": 50}},
+			{Name: "my_app.zero_variant_enabled", Weights: map[string]int{"false": 100, "true": 0}},
+		},
+	}
+	remote := &serializers.RemoteRegistry{
+		Splits: map[string]serializers.RemoteRegistrySplit{
+			"my_app.in_sync_experiment":          {Weights: map[string]int{"control": 50, "treatment": 50}},
+			"my_app.reweighted_experiment":       {Weights: map[string]int{"control": 60, "treatment": 40}},
+			"my_app.decided_experiment":          {Weights: map[string]int{"control": 50, "treatment": 50}},
+			"my_app.remotely_decided_experiment": {Weights: map[string]int{"control": 100}},
+			"my_app.zero_variant_enabled":        {Weights: map[string]int{"false": 100}},
+			"my_app.remote_only_enabled":         {Weights: map[string]int{"false": 100, "true": 0}},
+			"other_app.someone_elses_experiment": {Weights: map[string]int{"control": 50, "treatment": 50}},
+		},
+	}
+
+	drifts := schemadiffs.Diff(local, remote, "my_app")
+
+	kinds := map[string]string{}
+	for _, drift := range drifts {
+		kinds[drift.Split] = drift.Kind
+	}
+	require.Equal(t, map[string]string{
+		"my_app.local_only_enabled":          schemadiffs.MissingRemote,
+		"my_app.remote_only_enabled":         schemadiffs.MissingLocal,
+		"my_app.reweighted_experiment":       schemadiffs.WeightsMismatch,
+		"my_app.decided_experiment":          schemadiffs.DecisionMismatch,
+		"my_app.remotely_decided_experiment": schemadiffs.DecisionMismatch,
+	}, kinds)
+
+	for _, drift := range drifts {
+		if drift.Split == "my_app.decided_experiment" {
+			require.Equal(t, "decided locally on treatment but remote weights are control: 50, treatment: 50", drift.Description())
+		}
+	}
+}
@@ -97,22 +97,17 @@ func schemaSplitMigrations(schemaSplit serializers.SchemaSplit) ([]migrations.IM
 	migrations := []migrations.IMigration{split}
 
 	if schemaSplit.Decided {
-		var decision *string
 		weights, err := splits.NewWeights(schemaSplit.Weights)
 		if err != nil {
 			return nil, fmt.Errorf("schema split %s invalid: %w", schemaSplit.Name, err)
 		}
-		for variant, weight := range *weights {
-			if weight == 100 {
-				decision = &variant
-			}
-		}
-		if decision == nil {
+		decision, ok := weights.DecidedVariant()
+		if !ok {
 			return nil, fmt.Errorf("decided schema split %s has no 100%% weighted variant", schemaSplit.Name)
 		}
 		migrations = append(migrations, splitdecisions.FromFile(nil, &serializers.SplitDecision{
 			Split:   schemaSplit.Name,
-			Variant: *decision,
+			Variant: decision,
 		}))
 	}
 	return migrations, nil
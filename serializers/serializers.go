@@ -1,11 +1,29 @@
 package serializers
 
 import (
+	"errors"
+	"fmt"
+
 	"gopkg.in/yaml.v2"
 )
 
-// SerializerVersion is the current version of the migration file format so we can evolve over time
-const SerializerVersion = 1
+// SerializerVersion is the current version of the migration file format so we can evolve over time.
+// Version 2 adds optional fields and migration types that version 1 readers would silently drop.
+const SerializerVersion = 2
+
+// ErrNewerSerializerVersion is wrapped by errors for files written by a newer
+// version of testtrack than this one
+var ErrNewerSerializerVersion = errors.New("upgrade testtrack to read it")
+
+// CheckSerializerVersion returns an error if a file was written by a newer
+// version of testtrack than this one, because fields it doesn't know about
+// would be lost
+func CheckSerializerVersion(filename string, serializerVersion int) error {
+	if serializerVersion > SerializerVersion {
+		return fmt.Errorf("%s has serializer_version %d but this testtrack only supports up to %d, %w", filename, serializerVersion, SerializerVersion, ErrNewerSerializerVersion)
+	}
+	return nil
+}
 
 // MigrationVersion is a JSON-marshalable representation of migration version (timestamp)
 type MigrationVersion struct {
@@ -21,12 +39,56 @@ type MigrationFile struct {
 	SplitRetirement   *SplitRetirement   `yaml:"split_retirement,omitempty"`
 	SplitDecision     *SplitDecision     `yaml:"split_decision,omitempty"`
 	IdentifierType    *IdentifierType    `yaml:"identifier_type,omitempty"`
+	OwnershipTransfer *OwnershipTransfer `yaml:"ownership_transfer,omitempty"`
+}
+
+// Kind returns the snake_case name of the type of migration in the file
+func (f *MigrationFile) Kind() string {
+	switch {
+	case f.FeatureCompletion != nil:
+		return "feature_completion"
+	case f.RemoteKill != nil:
+		return "remote_kill"
+	case f.Split != nil:
+		return "split"
+	case f.SplitRetirement != nil:
+		return "split_retirement"
+	case f.SplitDecision != nil:
+		return "split_decision"
+	case f.IdentifierType != nil:
+		return "identifier_type"
+	case f.OwnershipTransfer != nil:
+		return "ownership_transfer"
+	}
+	return ""
+}
+
+// Resource returns the natural key of the resource the migration in the file affects
+func (f *MigrationFile) Resource() string {
+	switch {
+	case f.FeatureCompletion != nil:
+		return f.FeatureCompletion.FeatureGate
+	case f.RemoteKill != nil:
+		return f.RemoteKill.Split + ":" + f.RemoteKill.Reason
+	case f.Split != nil:
+		return f.Split.Name
+	case f.SplitRetirement != nil:
+		return f.SplitRetirement.Split
+	case f.SplitDecision != nil:
+		return f.SplitDecision.Split
+	case f.IdentifierType != nil:
+		return f.IdentifierType.Name
+	case f.OwnershipTransfer != nil:
+		return f.OwnershipTransfer.Split
+	}
+	return ""
 }
 
 // FeatureCompletion is the marshalable representation of a FeatureCompletion
 type FeatureCompletion struct {
 	FeatureGate string  `yaml:"feature_gate" json:"feature_gate"`
 	Version     *string `yaml:"version" json:"version"`
+	ExpiresAt   *string `yaml:"expires_at,omitempty" json:"expires_at,omitempty"`
 }
 
 // RemoteKill is the marshalable representation of a RemoteKill
@@ -36,6 +98,7 @@ type RemoteKill struct {
 	OverrideTo      *string `yaml:"override_to" json:"override_to"`
 	FirstBadVersion *string `yaml:"first_bad_version" json:"first_bad_version"`
 	FixedVersion    *string `yaml:"fixed_version" json:"fixed_version"`
+	ExpiresAt       *string `yaml:"expires_at,omitempty" json:"expires_at,omitempty"`
 }
 
 // SplitYAML is the YAML-marshalable representation of a Split
@@ -43,6 +106,24 @@ type SplitYAML struct {
 	Name    string         `yaml:"name"`
 	Weights map[string]int `yaml:"weights"`
 	Owner   string         `yaml:"owner,omitempty"`
+	Details *SplitDetails  `yaml:"details,omitempty"`
+}
+
+// SplitDetails is the marshalable representation of a split's documentation
+type SplitDetails struct {
+	Hypothesis         string          `yaml:"hypothesis,omitempty" json:"hypothesis,omitempty"`
+	Description        string          `yaml:"description,omitempty" json:"description,omitempty"`
+	AssignmentCriteria string          `yaml:"assignment_criteria,omitempty" json:"assignment_criteria,omitempty"`
+	Location           string          `yaml:"location,omitempty" json:"location,omitempty"`
+	Platform           string          `yaml:"platform,omitempty" json:"platform,omitempty"`
+	VariantDetails     []VariantDetail `yaml:"variant_details,omitempty" json:"variant_details,omitempty"`
+}
+
+// VariantDetail is the marshalable representation of a variant's documentation
+type VariantDetail struct {
+	Name          string `yaml:"name" json:"name"`
+	Description   string `yaml:"description,omitempty" json:"description,omitempty"`
+	ScreenshotURL string `yaml:"screenshot_url,omitempty" json:"screenshot_url,omitempty"`
 }
 
 // SplitJSON is the JSON-marshalabe representation of a Split
@@ -73,6 +154,12 @@ type SplitDecision struct {
 	Variant string `json:"variant"`
 }
 
+// OwnershipTransfer is the marshalable representation of a change of a split's owner
+type OwnershipTransfer struct {
+	Split string `yaml:"split" json:"split"`
+	Owner string `yaml:"owner" json:"owner"`
+}
+
 // IdentifierType is the JSON and YAML-marshalable representation of an IdentifierType
 type IdentifierType struct {
 	Name string `yaml:"name" json:"name"`
@@ -84,6 +171,7 @@ type SchemaSplit struct {
 	Weights map[string]int `yaml:"weights" json:"weights"`
 	Decided bool           `yaml:"decided,omitempty" json:"decided,omitempty"`
 	Owner   string         `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Details *SplitDetails  `yaml:"details,omitempty" json:"details,omitempty"`
 }
 
 // Schema is the YAML-marshalable representation of the TestTrack schema for
@@ -9,9 +9,16 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
+const defaultTimeout = 30 * time.Second
+const defaultMaxRetries = 3
+const defaultRetryBackoff = 500 * time.Millisecond
+const maxRetryDelay = 60 * time.Second
+
 // IServer is the interface of the TestTrack API client
 type IServer interface {
 	Get(path string, v interface{}) error
@@ -21,7 +28,13 @@ type IServer interface {
 
 // Server is the live implementation of the TestTrack API client
 type Server struct {
-	url *url.URL
+	url          *url.URL
+	client       *http.Client
+	maxRetries   int
+	retryBackoff time.Duration
+	username     string
+	password     string
+	bearerToken  string
 }
 
 // New returns a live TestTrack for use in API calls
@@ -30,13 +43,119 @@ func New() (IServer, error) {
 	if !ok {
 		return nil, errors.New("TESTTRACK_CLI_URL must be set")
 	}
+	return NewForURL(urlString)
+}
 
+// NewForURL returns a live TestTrack at a URL other than TESTTRACK_CLI_URL,
+// configured and authenticated from the environment like New
+func NewForURL(urlString string) (*Server, error) {
 	url, err := url.ParseRequestURI(urlString)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Server{url: url}, nil
+	timeout, err := durationFromEnv("TESTTRACK_CLI_TIMEOUT", defaultTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	maxRetries, err := intFromEnv("TESTTRACK_CLI_MAX_RETRIES", defaultMaxRetries)
+	if err != nil {
+		return nil, err
+	}
+
+	retryBackoff, err := durationFromEnv("TESTTRACK_CLI_RETRY_BACKOFF", defaultRetryBackoff)
+	if err != nil {
+		return nil, err
+	}
+
+	server := &Server{
+		url:          url,
+		client:       &http.Client{Timeout: timeout},
+		maxRetries:   maxRetries,
+		retryBackoff: retryBackoff,
+	}
+
+	err = server.configureCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	return server, nil
+}
+
+// configureCredentials reads credentials configured separately from
+// TESTTRACK_CLI_URL, which take precedence over any embedded in the URL
+func (s *Server) configureCredentials() error {
+	bearerToken, err := secretFromEnv("TESTTRACK_CLI_TOKEN")
+	if err != nil {
+		return err
+	}
+
+	password, err := secretFromEnv("TESTTRACK_CLI_PASSWORD")
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case bearerToken != "" && password != "":
+		return errors.New("TESTTRACK_CLI_TOKEN and TESTTRACK_CLI_PASSWORD are mutually exclusive")
+	case bearerToken != "":
+		s.bearerToken = bearerToken
+	case password != "":
+		username, ok := os.LookupEnv("TESTTRACK_APP_NAME")
+		if !ok {
+			return errors.New("TESTTRACK_APP_NAME must be set to authenticate with TESTTRACK_CLI_PASSWORD")
+		}
+		s.username = username
+		s.password = password
+	default:
+		return nil
+	}
+
+	s.url.User = nil
+	return nil
+}
+
+// secretFromEnv reads a secret from an env var, or from the file named by the
+// same env var suffixed with _FILE so the secret needn't be in the environment.
+// An empty env var counts as unset, since env files often leave placeholders
+// blank.
+func secretFromEnv(name string) (string, error) {
+	if value := os.Getenv(name); value != "" {
+		return value, nil
+	}
+	path := os.Getenv(name + "_FILE")
+	if path == "" {
+		return "", nil
+	}
+	secretBytes, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("couldn't read %s_FILE: %w", name, err)
+	}
+	return strings.TrimSpace(string(secretBytes)), nil
+}
+
+// URL returns a copy of the server's base URL, without any credentials that
+// are sent separately
+func (s *Server) URL() *url.URL {
+	url := *s.url
+	return &url
+}
+
+// Do sends a request authenticated like the API calls, without retrying, for
+// callers that need the server's raw response
+func (s *Server) Do(req *http.Request) (*http.Response, error) {
+	s.authorize(req)
+	return s.client.Do(req)
+}
+
+func (s *Server) authorize(req *http.Request) {
+	if s.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.bearerToken)
+	} else if s.password != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
 }
 
 // Get makes an authenticated GET to the TestTrack API
@@ -46,7 +165,7 @@ func (s *Server) Get(path string, v interface{}) error {
 		return err
 	}
 
-	resp, err := http.Get(url)
+	resp, err := s.do("GET", url, nil)
 	if err != nil {
 		return err
 	}
@@ -82,7 +201,7 @@ func (s *Server) Post(path string, body interface{}) (*http.Response, error) {
 		return nil, err
 	}
 
-	return http.Post(url, "application/json", bytes.NewReader(bodyBytes))
+	return s.do("POST", url, bodyBytes)
 }
 
 // Delete makes an authenticated DELETE to the TestTrack API
@@ -92,16 +211,12 @@ func (s *Server) Delete(path string) error {
 		return err
 	}
 
-	client := &http.Client{}
-	req, err := http.NewRequest("DELETE", url, nil)
+	resp, err := s.do("DELETE", url, nil)
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
 	if resp.StatusCode != 204 {
 		return fmt.Errorf("got %d status code", resp.StatusCode)
 	}
@@ -109,6 +224,102 @@ func (s *Server) Delete(path string) error {
 	return nil
 }
 
+// do makes a request, retrying network errors and retryable statuses with
+// exponential backoff. A Retry-After header from the server takes precedence
+// over the backoff. The last response is returned once retries run out.
+func (s *Server) do(method, url string, body []byte) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(body)
+		}
+
+		req, err := http.NewRequest(method, url, bodyReader)
+		if err != nil {
+			return nil, err
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		s.authorize(req)
+
+		resp, err := s.client.Do(req)
+		if attempt >= s.maxRetries {
+			return resp, err
+		}
+		if err == nil && !retryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		delay := s.retryBackoff << attempt
+		if err == nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				delay = retryAfter
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if delay > maxRetryDelay || delay < 0 {
+			delay = maxRetryDelay
+		}
+		time.Sleep(delay)
+	}
+}
+
+func retryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter supports both the delay-seconds and HTTP-date forms
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+func durationFromEnv(name string, defaultValue time.Duration) (time.Duration, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return defaultValue, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration like 30s: %w", name, err)
+	}
+	return duration, nil
+}
+
+func intFromEnv(name string, defaultValue int) (int, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return defaultValue, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer, got %s", name, value)
+	}
+	return i, nil
+}
+
 // Note that this operates on a copy to avoid mutating *s.url
 func (s Server) urlFor(path string) (string, error) {
 	s.url.Path = strings.TrimRight(s.url.Path, "/")
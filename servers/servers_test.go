@@ -0,0 +1,193 @@
+package servers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/servers"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T, handler http.HandlerFunc) servers.IServer {
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	t.Setenv("TESTTRACK_CLI_URL", ts.URL)
+	t.Setenv("TESTTRACK_CLI_RETRY_BACKOFF", "1ms")
+
+	server, err := servers.New()
+	require.NoError(t, err)
+	return server
+}
+
+func TestRetries(t *testing.T) {
+	t.Run("it retries retryable statuses until it succeeds", func(t *testing.T) {
+		attempts := 0
+		server := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`[{"version": "2020011774023"}]`))
+		})
+
+		var versions []map[string]string
+		err := server.Get("api/v2/migrations", &versions)
+		require.NoError(t, err)
+		require.Equal(t, 3, attempts)
+		require.Equal(t, "2020011774023", versions[0]["version"])
+	})
+
+	t.Run("it resends the body when retrying a post", func(t *testing.T) {
+		bodies := []string{}
+		server := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			body := make([]byte, r.ContentLength)
+			r.Body.Read(body)
+			bodies = append(bodies, string(body))
+			if len(bodies) == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		resp, err := server.Post("api/v2/migrations", map[string]string{"version": "2020011774023"})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+		require.Equal(t, []string{`{"version":"2020011774023"}`, `{"version":"2020011774023"}`}, bodies)
+	})
+
+	t.Run("it gives up after the configured number of retries", func(t *testing.T) {
+		t.Setenv("TESTTRACK_CLI_MAX_RETRIES", "1")
+		attempts := 0
+		server := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		err := server.Get("api/v2/migrations", &[]interface{}{})
+		require.EqualError(t, err, "got 502 status code")
+		require.Equal(t, 2, attempts)
+	})
+
+	t.Run("it doesn't retry client errors", func(t *testing.T) {
+		attempts := 0
+		server := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		})
+
+		resp, err := server.Post("api/v2/migrations/split", map[string]string{})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		require.Equal(t, 1, attempts)
+	})
+
+	t.Run("it times out slow requests", func(t *testing.T) {
+		t.Setenv("TESTTRACK_CLI_TIMEOUT", "20ms")
+		t.Setenv("TESTTRACK_CLI_MAX_RETRIES", "0")
+		server := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		})
+
+		err := server.Get("api/v2/migrations", &[]interface{}{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Timeout")
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("it rejects malformed configuration", func(t *testing.T) {
+		t.Setenv("TESTTRACK_CLI_URL", "https://testtrack.example.com")
+		t.Setenv("TESTTRACK_CLI_TIMEOUT", "thirty")
+
+		_, err := servers.New()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "TESTTRACK_CLI_TIMEOUT must be a duration")
+	})
+}
+
+func TestCredentials(t *testing.T) {
+	t.Run("it sends a bearer token", func(t *testing.T) {
+		t.Setenv("TESTTRACK_CLI_TOKEN", "s3cr3t")
+		var authorization string
+		server := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			w.Write([]byte(`[]`))
+		})
+
+		err := server.Get("api/v2/migrations", &[]interface{}{})
+		require.NoError(t, err)
+		require.Equal(t, "Bearer s3cr3t", authorization)
+	})
+
+	t.Run("it sends basic auth with the app name and a password read from a file", func(t *testing.T) {
+		passwordFile := filepath.Join(t.TempDir(), "password")
+		err := os.WriteFile(passwordFile, []byte("s3cr3t\n"), 0600)
+		require.NoError(t, err)
+		t.Setenv("TESTTRACK_CLI_PASSWORD_FILE", passwordFile)
+		t.Setenv("TESTTRACK_APP_NAME", "my_app")
+
+		var username, password string
+		server := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			username, password, _ = r.BasicAuth()
+			w.Write([]byte(`[]`))
+		})
+
+		err = server.Get("api/v2/migrations", &[]interface{}{})
+		require.NoError(t, err)
+		require.Equal(t, "my_app", username)
+		require.Equal(t, "s3cr3t", password)
+	})
+
+	t.Run("it reads a secret from a file when its env var is blank", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		err := os.WriteFile(tokenFile, []byte("s3cr3t\n"), 0600)
+		require.NoError(t, err)
+		t.Setenv("TESTTRACK_CLI_TOKEN", "")
+		t.Setenv("TESTTRACK_CLI_TOKEN_FILE", tokenFile)
+
+		var authorization string
+		server := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			w.Write([]byte(`[]`))
+		})
+
+		err = server.Get("api/v2/migrations", &[]interface{}{})
+		require.NoError(t, err)
+		require.Equal(t, "Bearer s3cr3t", authorization)
+	})
+
+	t.Run("it authenticates raw requests to another URL", func(t *testing.T) {
+		t.Setenv("TESTTRACK_CLI_TOKEN", "s3cr3t")
+		var authorization string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+		}))
+		t.Cleanup(ts.Close)
+
+		server, err := servers.NewForURL(ts.URL)
+		require.NoError(t, err)
+		req, err := http.NewRequest("GET", server.URL().String()+"/api/v2/split_registry", nil)
+		require.NoError(t, err)
+		resp, err := server.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, "Bearer s3cr3t", authorization)
+	})
+
+	t.Run("it rejects conflicting credentials", func(t *testing.T) {
+		t.Setenv("TESTTRACK_CLI_URL", "https://testtrack.example.com")
+		t.Setenv("TESTTRACK_CLI_TOKEN", "s3cr3t")
+		t.Setenv("TESTTRACK_CLI_PASSWORD", "s3cr3t")
+
+		_, err := servers.New()
+		require.EqualError(t, err, "TESTTRACK_CLI_TOKEN and TESTTRACK_CLI_PASSWORD are mutually exclusive")
+	})
+}
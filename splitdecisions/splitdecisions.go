@@ -112,7 +112,7 @@ func (s *SplitDecision) ApplyToSchema(schema *serializers.Schema, migrationRepo
 	if s.migrationVersion != nil {
 		split := splits.MostRecentNamed(*s.split, *s.migrationVersion, migrationRepo)
 		if split != nil {
-			weights := split.Weights()
+			weights := split.Weights().Copy()
 			err := weights.ReweightToDecision(*s.variant)
 			if err != nil {
 				return fmt.Errorf("in most recent split migration %s: %w", *s.split, err)
@@ -121,6 +121,8 @@ func (s *SplitDecision) ApplyToSchema(schema *serializers.Schema, migrationRepo
 				Name:    *s.split,
 				Weights: *weights,
 				Decided: true,
+				Owner:   splits.MostRecentOwner(*s.split, *s.migrationVersion, migrationRepo),
+				Details: splits.MostRecentDetails(*s.split, *s.migrationVersion, migrationRepo),
 			})
 			return nil
 		}
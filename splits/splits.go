@@ -26,10 +26,12 @@ type Split struct {
 	name             *string
 	weights          *Weights
 	owner            *string
+	details          *serializers.SplitDetails
 }
 
-// New returns a migration object
-func New(name *string, weights *Weights, owner *string) (migrations.IMigration, error) {
+// New returns a migration object. Details may be nil to keep a split's
+// existing documentation.
+func New(name *string, weights *Weights, owner *string, details *serializers.SplitDetails) (migrations.IMigration, error) {
 	migrationVersion, err := migrations.GenerateMigrationVersion()
 	if err != nil {
 		return nil, err
@@ -40,6 +42,7 @@ func New(name *string, weights *Weights, owner *string) (migrations.IMigration,
 		name:             name,
 		weights:          weights,
 		owner:            owner,
+		details:          details,
 	}, nil
 }
 
@@ -81,6 +84,11 @@ func IsFeatureGateFromName(name string) bool {
 	return strings.HasSuffix(name, "_enabled")
 }
 
+// IsExperimentFromName returns true if name ends with '_experiment'
+func IsExperimentFromName(name string) bool {
+	return strings.HasSuffix(name, "_experiment")
+}
+
 // FromFile reifies a migration from the yaml serializable representation
 func FromFile(migrationVersion *string, serializable *serializers.SplitYAML) (migrations.IMigration, error) {
 	weights, err := NewWeights(serializable.Weights)
@@ -92,12 +100,26 @@ func FromFile(migrationVersion *string, serializable *serializers.SplitYAML) (mi
 		name:             &serializable.Name,
 		owner:            &serializable.Owner,
 		weights:          weights,
+		details:          serializable.Details,
 	}, nil
 }
 
 // Validate validates that a feature completion may be persisted
 func (s *Split) Validate() error {
-	return validations.Split("name", s.name)
+	err := validations.Split("name", s.name)
+	if err != nil {
+		return err
+	}
+
+	if s.details != nil {
+		for _, variantDetail := range s.details.VariantDetails {
+			if _, ok := (*s.weights)[variantDetail.Name]; !ok {
+				return fmt.Errorf("variant_details variant '%s' must be one of the split's variants", variantDetail.Name)
+			}
+		}
+	}
+
+	return nil
 }
 
 // Filename generates a filename for this migration
@@ -114,6 +136,7 @@ func (s *Split) File() *serializers.MigrationFile {
 			Name:    *s.name,
 			Weights: *s.weights,
 			Owner:   *s.owner,
+			Details: s.details,
 		},
 	}
 }
@@ -160,27 +183,44 @@ func (s *Split) ApplyToSchema(schema *serializers.Schema, migrationRepo migratio
 			schemaWeights.Merge(*s.weights)
 			schema.Splits[i].Decided = false
 			schema.Splits[i].Weights = *schemaWeights
+			if *s.owner != "" {
+				schema.Splits[i].Owner = *s.owner
+			}
+			if s.details != nil {
+				schema.Splits[i].Details = s.details
+			}
 			return nil
 		}
 	}
 	if s.migrationVersion != nil { // Revive weights from old migration
 		split := MostRecentNamed(*s.name, *s.migrationVersion, migrationRepo)
 		if split != nil {
-			weights := split.Weights()
+			weights := split.Weights().Copy()
 			weights.Merge(*s.weights)
+			owner := *s.owner
+			if owner == "" {
+				owner = MostRecentOwner(*s.name, *s.migrationVersion, migrationRepo)
+			}
+			details := s.details
+			if details == nil {
+				details = MostRecentDetails(*s.name, *s.migrationVersion, migrationRepo)
+			}
 			schema.Splits = append(schema.Splits, serializers.SchemaSplit{
 				Name:    *s.name,
 				Weights: *weights,
 				Decided: false,
+				Owner:   owner,
+				Details: details,
 			})
 			return nil
 		}
 	}
 	schemaSplit := serializers.SchemaSplit{ // Create
 		Name:    *s.name,
-		Weights: *s.weights,
+		Weights: *s.weights.Copy(),
 		Decided: false,
 		Owner:   *s.owner,
+		Details: s.details,
 	}
 	schema.Splits = append(schema.Splits, schemaSplit)
 	return nil
@@ -212,3 +252,40 @@ func MostRecentNamed(name, migrationVersion string, migrationRepo migrations.Rep
 	}
 	return nil
 }
+
+// MostRecentOwner returns the owner a split was most recently given by a split
+// or ownership transfer migration before migrationVersion, so that owners
+// survive retirement and revival
+func MostRecentOwner(name, migrationVersion string, migrationRepo migrations.Repository) string {
+	versions := migrationRepo.SortedVersions()
+	for i := len(versions) - 1; i >= 0; i-- {
+		if versions[i] >= migrationVersion {
+			continue
+		}
+		file := migrationRepo[versions[i]].File()
+		if file.Split != nil && file.Split.Name == name && file.Split.Owner != "" {
+			return file.Split.Owner
+		}
+		if file.OwnershipTransfer != nil && file.OwnershipTransfer.Split == name {
+			return file.OwnershipTransfer.Owner
+		}
+	}
+	return ""
+}
+
+// MostRecentDetails returns the details a split was most recently documented
+// with by a split migration before migrationVersion, so that documentation
+// survives retirement and revival
+func MostRecentDetails(name, migrationVersion string, migrationRepo migrations.Repository) *serializers.SplitDetails {
+	versions := migrationRepo.SortedVersions()
+	for i := len(versions) - 1; i >= 0; i-- {
+		if versions[i] >= migrationVersion {
+			continue
+		}
+		split, ok := migrationRepo[versions[i]].(*Split)
+		if ok && *split.name == name && split.details != nil {
+			return split.details
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package splits_test
+
+import (
+	"testing"
+
+	"github.com/Betterment/testtrack-cli/internal/testutil"
+	"github.com/Betterment/testtrack-cli/splits"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMostRecentOwner(t *testing.T) {
+	name := "my_app.fancy_experiment"
+	weights := splits.Weights{"control": 50, "treatment": 50}
+
+	fixtures := testutil.NewRepoBuilder(t)
+	fixtures.Split("2020010100001", name, "growth", weights)
+	fixtures.Split("2020010100002", "my_app.other_experiment", "platform", weights)
+	fixtures.Split("2020010100003", name, "", weights)
+	fixtures.OwnershipTransfer("2020010100004", name, "checkout")
+	fixtures.Retirement("2020010100005", name, "control")
+	fixtures.Split("2020010100006", name, "search", weights)
+
+	t.Run("it ignores migrations at or after the version", func(t *testing.T) {
+		require.Equal(t, "", splits.MostRecentOwner(name, "2020010100001", fixtures.Repo))
+		require.Equal(t, "growth", splits.MostRecentOwner(name, "2020010100002", fixtures.Repo))
+	})
+
+	t.Run("it skips splits migrated without an owner", func(t *testing.T) {
+		require.Equal(t, "growth", splits.MostRecentOwner(name, "2020010100004", fixtures.Repo))
+	})
+
+	t.Run("it follows ownership transfers through retirement", func(t *testing.T) {
+		require.Equal(t, "checkout", splits.MostRecentOwner(name, "2020010100006", fixtures.Repo))
+	})
+
+	t.Run("it uses the most recent owner", func(t *testing.T) {
+		require.Equal(t, "search", splits.MostRecentOwner(name, "2020010199999", fixtures.Repo))
+	})
+}
@@ -2,6 +2,8 @@ package splits
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // Weights represents the weightings of a split
@@ -23,6 +25,15 @@ func NewWeights(weights map[string]int) (*Weights, error) {
 	return &w, nil
 }
 
+// Copy returns weights that can be modified without affecting the original
+func (w *Weights) Copy() *Weights {
+	weights := make(Weights, len(*w))
+	for variant, weight := range *w {
+		weights[variant] = weight
+	}
+	return &weights
+}
+
 // Merge newWeights over weights
 func (w *Weights) Merge(newWeights Weights) {
 	for variant := range *w {
@@ -41,3 +52,63 @@ func (w *Weights) ReweightToDecision(variant string) error {
 	w.Merge(Weights{variant: 100})
 	return nil
 }
+
+// Equal returns whether weights match other, treating missing variants as
+// zero-weighted
+func (w *Weights) Equal(other Weights) bool {
+	for variant, weight := range *w {
+		if other[variant] != weight {
+			return false
+		}
+	}
+	for variant, weight := range other {
+		if (*w)[variant] != weight {
+			return false
+		}
+	}
+	return true
+}
+
+// DecidedVariant returns the variant weighted at 100%, if any
+func (w *Weights) DecidedVariant() (string, bool) {
+	for variant, weight := range *w {
+		if weight == 100 {
+			return variant, true
+		}
+	}
+	return "", false
+}
+
+// VariantForBucket returns the variant whose share of the 0-99 bucket range,
+// allotted in alphabetical order of variant name, contains the bucket
+func (w *Weights) VariantForBucket(bucket int) string {
+	variants := make([]string, 0, len(*w))
+	for variant := range *w {
+		variants = append(variants, variant)
+	}
+	sort.Strings(variants)
+
+	cumulativeWeight := 0
+	for _, variant := range variants {
+		cumulativeWeight += (*w)[variant]
+		if bucket < cumulativeWeight {
+			return variant
+		}
+	}
+	return ""
+}
+
+// String formats weights as `variant: 0, another_variant: 100` in variant order
+func (w *Weights) String() string {
+	variants := make([]string, 0, len(*w))
+	for variant := range *w {
+		variants = append(variants, variant)
+	}
+	sort.Strings(variants)
+
+	records := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		records = append(records, fmt.Sprintf("%s: %d", variant, (*w)[variant]))
+	}
+	return strings.Join(records, ", ")
+}
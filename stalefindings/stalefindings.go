@@ -0,0 +1,168 @@
+package stalefindings
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/histories"
+	"github.com/Betterment/testtrack-cli/migrations"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/splits"
+)
+
+// Kinds of stale resources
+const (
+	UndecidedExperiment  = "undecided_experiment"
+	UnretiredDecision    = "unretired_decision"
+	CompletedFeatureGate = "completed_feature_gate"
+	OldRemoteKill        = "old_remote_kill"
+)
+
+// Finding describes a resource that has been left alone long enough that it
+// probably needs cleaning up, and the command that would clean it up
+type Finding struct {
+	Kind     string `json:"kind"`
+	Resource string `json:"resource"`
+	Days     int    `json:"days"`
+	Message  string `json:"message"`
+	Command  string `json:"command"`
+}
+
+// Find reports splits and remote kills in a schema whose current state is
+// older than days, aging them by the versions of the migrations that put them
+// in that state. Resources whose state predates migrations (i.e. came from a
+// legacy schema) can't be aged and are skipped.
+func Find(schema *serializers.Schema, migrationRepo migrations.Repository, appName string, days int, now time.Time) []Finding {
+	findings := []Finding{}
+
+	for _, split := range schema.Splits {
+		entries := histories.ForSplit(split.Name, migrationRepo)
+		name, flags, ok := commandName(split.Name, appName)
+
+		switch {
+		case split.Decided:
+			entry, found := lastEntry(entries, histories.ActionDecide)
+			age := ageInDays(entry.Time, now)
+			if !found || age < days {
+				continue
+			}
+			weights := splits.Weights(split.Weights)
+			variant, _ := weights.DecidedVariant()
+			findings = append(findings, Finding{
+				Kind:     UnretiredDecision,
+				Resource: split.Name,
+				Days:     age,
+				Message:  fmt.Sprintf("decided on %s %d days ago but never retired", variant, age),
+				Command:  command(ok, "testtrack destroy split %s --decision %s%s", name, variant, flags),
+			})
+		case splits.IsFeatureGateFromName(split.Name) && split.Weights["true"] == 100:
+			entry, found := lastEntry(entries, histories.ActionCreate, histories.ActionReweight)
+			age := ageInDays(entry.Time, now)
+			if !found || age < days {
+				continue
+			}
+			findings = append(findings, Finding{
+				Kind:     CompletedFeatureGate,
+				Resource: split.Name,
+				Days:     age,
+				Message:  fmt.Sprintf("enabled for 100%% of visitors for %d days", age),
+				Command:  command(ok, "testtrack destroy split %s --decision true%s", name, flags),
+			})
+		case splits.IsExperimentFromName(split.Name):
+			entry, found := lastEntry(entries, histories.ActionCreate)
+			age := ageInDays(entry.Time, now)
+			if !found || age < days {
+				continue
+			}
+			findings = append(findings, Finding{
+				Kind:     UndecidedExperiment,
+				Resource: split.Name,
+				Days:     age,
+				Message:  fmt.Sprintf("running undecided for %d days", age),
+				Command:  command(ok, "testtrack decide %s --variant <%s>%s", name, strings.Join(variants(split.Weights), "|"), flags),
+			})
+		}
+	}
+
+	for _, remoteKill := range schema.RemoteKills {
+		if remoteKill.FixedVersion == nil || *remoteKill.FixedVersion == "" {
+			continue // Unfixed remote kills are still protecting clients
+		}
+		version, found := lastRemoteKillVersion(remoteKill, migrationRepo)
+		if !found {
+			continue
+		}
+		versionTime, _ := migrations.VersionTime(version)
+		age := ageInDays(versionTime, now)
+		if age < days {
+			continue
+		}
+		name, flags, ok := commandName(remoteKill.Split, appName)
+		findings = append(findings, Finding{
+			Kind:     OldRemoteKill,
+			Resource: remoteKill.Split + ":" + remoteKill.Reason,
+			Days:     age,
+			Message:  fmt.Sprintf("fixed in version %s, last changed %d days ago", *remoteKill.FixedVersion, age),
+			Command:  command(ok, "testtrack destroy remote_kill %s %s%s", name, remoteKill.Reason, flags),
+		})
+	}
+
+	return findings
+}
+
+// commandName returns the split name and flags to pass to commands that
+// auto-prefix split names with the app name. Splits prefixed with another
+// app's name can't be changed from this project.
+func commandName(split, appName string) (string, string, bool) {
+	if strings.HasPrefix(split, appName+".") {
+		return strings.TrimPrefix(split, appName+"."), "", true
+	}
+	if !strings.Contains(split, ".") {
+		return split, " --no-prefix", true
+	}
+	return "", "", false
+}
+
+func command(ok bool, format string, args ...interface{}) string {
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+func lastEntry(entries []histories.Entry, actions ...string) (histories.Entry, bool) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		for _, action := range actions {
+			if entries[i].Action == action {
+				return entries[i], true
+			}
+		}
+	}
+	return histories.Entry{}, false
+}
+
+func lastRemoteKillVersion(remoteKill serializers.RemoteKill, migrationRepo migrations.Repository) (string, bool) {
+	versions := migrationRepo.SortedVersions()
+	for i := len(versions) - 1; i >= 0; i-- {
+		candidate := migrationRepo[versions[i]].File().RemoteKill
+		if candidate != nil && candidate.Split == remoteKill.Split && candidate.Reason == remoteKill.Reason {
+			return versions[i], true
+		}
+	}
+	return "", false
+}
+
+func ageInDays(t, now time.Time) int {
+	return int(now.Sub(t).Hours() / 24)
+}
+
+func variants(weights map[string]int) []string {
+	result := make([]string, 0, len(weights))
+	for variant := range weights {
+		result = append(result, variant)
+	}
+	sort.Strings(result)
+	return result
+}
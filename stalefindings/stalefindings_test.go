@@ -0,0 +1,59 @@
+package stalefindings_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Betterment/testtrack-cli/internal/testutil"
+	"github.com/Betterment/testtrack-cli/serializers"
+	"github.com/Betterment/testtrack-cli/splits"
+	"github.com/Betterment/testtrack-cli/stalefindings"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFind(t *testing.T) {
+	fixtures := testutil.NewRepoBuilder(t)
+	experimentWeights := splits.Weights{"control": 50, "treatment": 50}
+	fixtures.Split("2020010100000", "my_app.old_experiment", "", experimentWeights)
+	fixtures.Split("2020060100000", "my_app.new_experiment", "", experimentWeights)
+	fixtures.Split("2019120100000", "legacy_experiment", "", experimentWeights)
+	fixtures.Split("2020010100001", "my_app.decided_experiment", "", experimentWeights)
+	fixtures.Decision("2020010200000", "my_app.decided_experiment", "treatment")
+	fixtures.Split("2020010100002", "my_app.launched_enabled", "", splits.Weights{"false": 0, "true": 100})
+	fixtures.Split("2020010100003", "my_app.dark_enabled", "", splits.Weights{"false": 100, "true": 0})
+	split, reason, overrideTo, firstBad, fixed := "my_app.old_experiment", "crash", "control", "1.0", "1.1"
+	fixtures.RemoteKill("2020010300000", split, reason, &overrideTo, &firstBad, &fixed)
+
+	schema := &serializers.Schema{
+		Splits: []serializers.SchemaSplit{
+			{Name: "legacy_experiment", Weights: experimentWeights},
+			{Name: "my_app.dark_enabled", Weights: map[string]int{"false": 100, "true": 0}},
+			{Name: "my_app.decided_experiment", Weights: map[string]int{"control": 0, "treatment": 100}, Decided: true},
+			{Name: "my_app.launched_enabled", Weights: map[string]int{"false": 0, "true": 100}},
+			{Name: "my_app.new_experiment", Weights: experimentWeights},
+			{Name: "my_app.old_experiment", Weights: experimentWeights},
+			{Name: "other_app.their_experiment", Weights: experimentWeights},
+		},
+		RemoteKills: []serializers.RemoteKill{
+			{Split: split, Reason: reason, OverrideTo: &overrideTo, FirstBadVersion: &firstBad, FixedVersion: &fixed},
+		},
+	}
+	now := time.Date(2020, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	findings := stalefindings.Find(schema, fixtures.Repo, "my_app", 90, now)
+
+	commands := map[string]string{}
+	days := map[string]int{}
+	for _, finding := range findings {
+		commands[finding.Resource] = finding.Command
+		days[finding.Resource] = finding.Days
+	}
+	require.Equal(t, map[string]string{
+		"legacy_experiment":           "testtrack decide legacy_experiment --variant <control|treatment> --no-prefix",
+		"my_app.decided_experiment":   "testtrack destroy split decided_experiment --decision treatment",
+		"my_app.launched_enabled":     "testtrack destroy split launched_enabled --decision true",
+		"my_app.old_experiment":       "testtrack decide old_experiment --variant <control|treatment>",
+		"my_app.old_experiment:crash": "testtrack destroy remote_kill old_experiment crash",
+	}, commands)
+	require.Equal(t, 181, days["my_app.old_experiment"])
+}
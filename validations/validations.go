@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/Betterment/testtrack-cli/serializers"
 	"gopkg.in/yaml.v2"
@@ -13,6 +15,9 @@ import (
 const appVersionMaxLength = 18 // This conforms to iOS version numering rules
 const splitMaxLength = 128     // This is arbitrary but way bigger than you need and smaller than the column will fit
 
+// DateLayout is the format of dates in migrations, e.g. expires_at
+const DateLayout = "2006-01-02"
+
 // DefaultOwnershipFilePath defines the default path to a YML file listing the possible split owners
 const DefaultOwnershipFilePath = "testtrack/owners.yml"
 
@@ -73,10 +78,7 @@ func AutoPrefixAndValidateSplit(paramName string, value *string, currentAppName
 // ValidateOwnerName ensures that if a testtrack/owners.yml file is present, the owner matches
 // the list of owners in that file.
 func ValidateOwnerName(owner string) error {
-	ownershipFilePath, ok := os.LookupEnv("TESTTRACK_OWNERSHIP_FILE")
-	if !ok {
-		ownershipFilePath = DefaultOwnershipFilePath
-	}
+	ownershipFilePath := OwnershipFilePath()
 
 	// If no ownership file exists, force owner to be empty. Otherwise pass validations.
 	_, err := os.Stat(ownershipFilePath)
@@ -111,6 +113,40 @@ func ValidateOwnerName(owner string) error {
 	return nil
 }
 
+// OwnershipFilePath returns the path to the ownership file, which may not exist
+func OwnershipFilePath() string {
+	ownershipFilePath, ok := os.LookupEnv("TESTTRACK_OWNERSHIP_FILE")
+	if !ok {
+		ownershipFilePath = DefaultOwnershipFilePath
+	}
+	return ownershipFilePath
+}
+
+// Owners returns the sorted owners defined in the ownership file, or nil if
+// there is no ownership file
+func Owners() ([]string, error) {
+	fileBytes, err := os.ReadFile(OwnershipFilePath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ownersMap := make(map[string]*struct{})
+	err = yaml.Unmarshal(fileBytes, ownersMap)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make([]string, 0, len(ownersMap))
+	for owner := range ownersMap {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+	return owners, nil
+}
+
 func mapContainsValue(value string, m map[string]*struct{}) bool {
 	for key := range m {
 		if key == value {
@@ -237,6 +273,17 @@ func OptionalAppVersion(paramName string, value *string) error {
 	return nil
 }
 
+// OptionalDate validates that a param, if present, is a YYYY-MM-DD date
+func OptionalDate(paramName string, value *string) error {
+	if value != nil && len(*value) > 0 {
+		_, err := time.Parse(DateLayout, *value)
+		if err != nil {
+			return fmt.Errorf("%s '%s' must be a date formatted YYYY-MM-DD", paramName, *value)
+		}
+	}
+	return nil
+}
+
 // SplitExistsInSchema validates that a split exists in the schema
 func SplitExistsInSchema(paramName string, value *string, schema *serializers.Schema) error {
 	err := Presence(paramName, value)